@@ -1,24 +1,107 @@
 package main
 
 import (
-	"log"
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
 
 	"react-ts/backend/config"
 	"react-ts/backend/internal/api"
 	"react-ts/backend/internal/bootstrap"
+	"react-ts/backend/internal/logging"
+	"react-ts/backend/internal/tracing"
+
+	"github.com/gin-gonic/gin"
 )
 
+// 設定ファイルの更新を確認する間隔
+const configWatchInterval = 5 * time.Second
+
 func main() {
+	if err := run(); err != nil {
+		slog.Error("application terminated", "error", err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
 
 	// 設定を読み込み
-	cfg, err := config.Load()
+	args := os.Args[1:]
+	cfg, opts, err := config.Load(args)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	// 有効な設定を表示して終了
+	if opts.PrintConfig {
+		fmt.Print(cfg)
+		return nil
+	}
+
+	// ロガーの設定
+	logger, err := logging.New(os.Stdout, cfg.Log.Level, cfg.Log.Format)
 	if err != nil {
-		log.Fatalf("failed to load configuration: %v", err)
+		return fmt.Errorf("failed to create logger: %w", err)
+	}
+	slog.SetDefault(logger)
+	configureGin(cfg.Profile)
+
+	// SIGINT/SIGTERMで停止する
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// 設定ファイルの更新またはSIGHUPで設定を再読み込みする
+	store := config.NewStore(cfg, opts, args)
+	store.Subscribe(func(c config.Config) {
+		if err := logging.SetLevel(c.Log.Level); err != nil {
+			slog.Error("failed to change log level", "error", err)
+		}
+	})
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+	go store.Watch(ctx, configWatchInterval, hup)
+
+	// トレースの設定
+	shutdownTracing, err := tracing.Setup(ctx, cfg)
+	if err != nil {
+		return fmt.Errorf("failed to set up tracing: %w", err)
 	}
 
 	// 依存関係の設定
-	cp := bootstrap.NewComponents()
+	cp := bootstrap.NewComponents(cfg)
 
-	// サーバー起動
-	api.Run(cfg, cp)
+	// サーバー起動（停止要求を受けるまで戻らない）
+	runErr := api.Run(ctx, store, cp)
+
+	// リクエストの処理が終わってからリソースを解放する
+	closeCtx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+	defer cancel()
+	if err := cp.Close(closeCtx); err != nil {
+		slog.Error("failed to close components", "error", err)
+	}
+	if err := shutdownTracing(closeCtx); err != nil {
+		slog.Error("failed to shut down tracing", "error", err)
+	}
+	slog.Info("server stopped")
+
+	return runErr
+}
+
+// configureGin はginのモードとデバッグ出力を設定します
+// dev以外のプロファイルではリリースモードとし、devではデバッグ出力（ルートの一覧など）をslogのDEBUGとして出力します
+func configureGin(profile string) {
+	if profile != config.ProfileDev {
+		gin.SetMode(gin.ReleaseMode)
+		return
+	}
+	gin.DebugPrintFunc = func(format string, values ...any) {
+		slog.Debug(strings.TrimSpace(fmt.Sprintf(format, values...)), "component", "gin")
+	}
 }
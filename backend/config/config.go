@@ -1,38 +1,642 @@
 package config
 
 import (
+	"errors"
+	"flag"
 	"fmt"
 	"os"
+	"strings"
+	"time"
+	// タイムゾーンのデータベースがない環境（distrolessなど）でも tracks.timeZone を読み込めるよう埋め込む
+	_ "time/tzdata"
 
 	"github.com/joho/godotenv"
 )
 
+// プロファイル
+const (
+	ProfileDev        = "dev"
+	ProfileStaging    = "staging"
+	ProfileProduction = "production"
+)
+
 // Config はアプリケーションの設定を保持します。
+//
+// 各項目は以下の順に読み込まれ、後のものほど優先されます。
+//  1. プロファイルごとのデフォルト値
+//  2. 設定ファイル（YAML/TOML）およびプロファイル別の設定ファイル
+//  3. 環境変数（.envファイルを含む）
+//  4. コマンドライン引数
+//
+// タグの意味は以下のとおりです。
+//   - env: 上書きに使用する環境変数名
+//   - flag: 上書きに使用するコマンドライン引数名
+//   - secret: 表示時にマスクする項目
+//   - reload: 再起動せずに再読み込みで変更できる項目
 type Config struct {
-	AllowOrigin string
-	Port        string
+	Profile     string            `yaml:"profile"`
+	Server      ServerConfig      `yaml:"server"`
+	TLS         TLSConfig         `yaml:"tls"`
+	Database    DatabaseConfig    `yaml:"database"`
+	Cache       CacheConfig       `yaml:"cache"`
+	Auth        AuthConfig        `yaml:"auth"`
+	Log         LogConfig         `yaml:"log"`
+	CORS        CORSConfig        `yaml:"cors"`
+	RateLimit   RateLimitConfig   `yaml:"rateLimit"`
+	HTTPCache   HTTPCacheConfig   `yaml:"httpCache"`
+	Compression CompressionConfig `yaml:"compression"`
+	I18n        I18nConfig        `yaml:"i18n"`
+	Errors      ErrorsConfig      `yaml:"errors"`
+	Events      EventsConfig      `yaml:"events"`
+	Positions   PositionsConfig   `yaml:"positions"`
+	Tracks      TracksConfig      `yaml:"tracks"`
+	Geofence    GeofenceConfig    `yaml:"geofence"`
+	Metrics     MetricsConfig     `yaml:"metrics"`
+	Trace       TraceConfig       `yaml:"trace"`
+
+	// 機能フラグ（機能名 → 有効/無効）
+	Features map[string]bool `yaml:"features" reload:"true"`
+}
+
+// 機能フラグの機能名
+const (
+	// Swagger UI（/swagger）を公開する
+	FeatureSwagger = "swagger"
+)
+
+// FeatureEnabled は機能フラグが有効かどうかを返します。
+func (c Config) FeatureEnabled(name string) bool {
+	return c.Features[name]
+}
+
+// ServerConfig はHTTPサーバーの設定です。
+type ServerConfig struct {
+	Port              string        `yaml:"port" env:"PORT" flag:"port"`
+	ReadTimeout       time.Duration `yaml:"readTimeout" env:"SERVER_READ_TIMEOUT"`
+	ReadHeaderTimeout time.Duration `yaml:"readHeaderTimeout" env:"SERVER_READ_HEADER_TIMEOUT"`
+	WriteTimeout      time.Duration `yaml:"writeTimeout" env:"SERVER_WRITE_TIMEOUT"`
+	IdleTimeout       time.Duration `yaml:"idleTimeout" env:"SERVER_IDLE_TIMEOUT"`
+	MaxHeaderBytes    int           `yaml:"maxHeaderBytes" env:"SERVER_MAX_HEADER_BYTES"`
+	// 停止時に処理中のリクエストの完了を待つ時間
+	ShutdownTimeout time.Duration `yaml:"shutdownTimeout" env:"SERVER_SHUTDOWN_TIMEOUT"`
+	// リクエストの処理時間の上限（超えた場合はcontextをキャンセルし、TIMEOUTのエラーを返します）
+	// エラーレスポンスを書き込めるよう、WriteTimeoutより短い値を指定してください。
+	RequestTimeout time.Duration `yaml:"requestTimeout" env:"SERVER_REQUEST_TIMEOUT" reload:"true"`
+	// パスの接頭辞ごとの処理時間の上限。最も長く一致した接頭辞の値を適用し、0の場合は上限を設けません（ストリーミングなど）。
+	RouteTimeouts map[string]time.Duration `yaml:"routeTimeouts" reload:"true"`
+	// X-Forwarded-For・X-Real-IP をクライアントIPとして信頼するリバースプロキシのIPアドレスまたはCIDR。
+	// 空の場合はヘッダーを信頼せず、接続元のアドレスをクライアントIPとします（ヘッダーの偽装でレート制限を回避できないよう）。
+	TrustedProxies []string `yaml:"trustedProxies" env:"SERVER_TRUSTED_PROXIES"`
+}
+
+// RequestTimeoutFor は path に適用する処理時間の上限を返します。0の場合は上限を設けません。
+func (c ServerConfig) RequestTimeoutFor(path string) time.Duration {
+	if prefix := longestPrefix(c.RouteTimeouts, path); prefix != "" {
+		return c.RouteTimeouts[prefix]
+	}
+	return c.RequestTimeout
+}
+
+// TLSConfig はTLSの設定です。CertFile/KeyFile または SelfSigned を指定するとTLSで待ち受けます。
+type TLSConfig struct {
+	CertFile string `yaml:"certFile" env:"TLS_CERT_FILE" flag:"tls-cert"`
+	KeyFile  string `yaml:"keyFile" env:"TLS_KEY_FILE" flag:"tls-key"`
+	// 証明書ファイルの更新を確認する間隔（更新された場合は再起動せずに読み込み直します）
+	ReloadInterval time.Duration `yaml:"reloadInterval" env:"TLS_RELOAD_INTERVAL"`
+	// 最小のTLSバージョン (1.2, 1.3)
+	MinVersion string `yaml:"minVersion" env:"TLS_MIN_VERSION"`
+	// クライアント証明書の要求 (none, optional, require)
+	ClientAuth string `yaml:"clientAuth" env:"TLS_CLIENT_AUTH"`
+	// クライアント証明書を検証するCA証明書
+	ClientCAFile string `yaml:"clientCAFile" env:"TLS_CLIENT_CA_FILE"`
+	// ローカル開発用に自己署名証明書を生成して使用する
+	SelfSigned bool `yaml:"selfSigned" env:"TLS_SELF_SIGNED" flag:"tls-self-signed"`
+	// 自己署名証明書に含めるホスト名・IPアドレス
+	SelfSignedHosts []string `yaml:"selfSignedHosts" env:"TLS_SELF_SIGNED_HOSTS"`
+	// HTTP/2を有効にする
+	HTTP2 bool `yaml:"http2" env:"TLS_HTTP2"`
+	// HTTP/3（QUIC）でも待ち受ける（server.portと同じ番号のUDPポート）
+	HTTP3 bool `yaml:"http3" env:"TLS_HTTP3"`
+}
+
+// Enabled はTLSで待ち受けるかどうかを返します。
+func (c TLSConfig) Enabled() bool {
+	return c.SelfSigned || c.CertFile != "" || c.KeyFile != ""
+}
+
+// DatabaseConfig はデータベース接続の設定です。
+type DatabaseConfig struct {
+	DSN             string        `yaml:"dsn" env:"DATABASE_DSN" secret:"true"`
+	MaxOpenConns    int           `yaml:"maxOpenConns" env:"DATABASE_MAX_OPEN_CONNS"`
+	MaxIdleConns    int           `yaml:"maxIdleConns" env:"DATABASE_MAX_IDLE_CONNS"`
+	ConnMaxLifetime time.Duration `yaml:"connMaxLifetime" env:"DATABASE_CONN_MAX_LIFETIME"`
+}
+
+// CacheConfig はリポジトリの参照結果をメモリにキャッシュする設定です。
+type CacheConfig struct {
+	Enabled bool `yaml:"enabled" env:"CACHE_ENABLED"`
+	// リポジトリごとにキャッシュする最大件数（超えた場合は最も長く参照されていないものから破棄します）
+	Size int `yaml:"size" env:"CACHE_SIZE"`
+	// キャッシュの有効期間
+	TTL time.Duration `yaml:"ttl" env:"CACHE_TTL"`
+	// キャッシュする値の読み込みの上限時間（読み込みはリクエストのキャンセルに関係なく続くため）
+	LoadTimeout time.Duration `yaml:"loadTimeout" env:"CACHE_LOAD_TIMEOUT"`
+}
+
+// AuthConfig は認証の設定です。
+type AuthConfig struct {
+	JWTSecret string        `yaml:"jwtSecret" env:"AUTH_JWT_SECRET" secret:"true"`
+	TokenTTL  time.Duration `yaml:"tokenTTL" env:"AUTH_TOKEN_TTL"`
+	APIKeys   []string      `yaml:"apiKeys" env:"AUTH_API_KEYS" secret:"true"`
+}
+
+// LogConfig はログ出力の設定です。
+type LogConfig struct {
+	// ログレベル (debug, info, warn, error)
+	Level string `yaml:"level" env:"LOG_LEVEL" flag:"log-level" reload:"true"`
+	// ログ形式 (json, text)
+	Format string `yaml:"format" env:"LOG_FORMAT" flag:"log-format"`
+	// アクセスログでマスクするクエリパラメータ名
+	RedactParams []string `yaml:"redactParams" env:"LOG_REDACT_PARAMS"`
+}
+
+// CORSConfig はCORSの設定です。
+type CORSConfig struct {
+	// 全てのルートに適用するポリシー
+	Default CORSPolicy `yaml:"default"`
+	// パスの接頭辞（/v1/admin など）ごとのポリシー。最も長く一致した接頭辞のポリシーを適用します。
+	// 指定しなかった項目はデフォルトのポリシーを引き継ぎます（AllowCredentialsを除く）。
+	Routes map[string]CORSPolicy `yaml:"routes" reload:"true"`
+}
+
+// CORSPolicy はCORSのポリシーです。
+type CORSPolicy struct {
+	// アクセスを許可するオリジン。"https://*.example.com" のようにサブドメインのワイルドカードを指定できます
+	AllowOrigins []string `yaml:"allowOrigins" env:"ALLOW_ORIGIN" reload:"true"`
+	// 許可するHTTPメソッド
+	AllowMethods []string `yaml:"allowMethods" env:"CORS_ALLOW_METHODS" reload:"true"`
+	// 許可するリクエストヘッダー
+	AllowHeaders []string `yaml:"allowHeaders" env:"CORS_ALLOW_HEADERS" reload:"true"`
+	// ブラウザから参照を許可するレスポンスヘッダー
+	ExposeHeaders []string `yaml:"exposeHeaders" env:"CORS_EXPOSE_HEADERS" reload:"true"`
+	// Cookieや認証情報を含むリクエストを許可するか
+	AllowCredentials bool `yaml:"allowCredentials" env:"CORS_ALLOW_CREDENTIALS" reload:"true"`
+	// preflightリクエストの結果をキャッシュする時間
+	MaxAge time.Duration `yaml:"maxAge" env:"CORS_MAX_AGE" reload:"true"`
 }
 
-// Load は .env ファイルと環境変数から設定を読み込みます。
-func Load() (Config, error) {
+// Route は path に最も長く一致するRoutesの接頭辞を返します。一致しない場合は空文字を返します。
+func (c CORSConfig) Route(path string) string {
+	return longestPrefix(c.Routes, path)
+}
+
+// Policy は path に適用するポリシーを返します。
+func (c CORSConfig) Policy(path string) CORSPolicy {
+	prefix := c.Route(path)
+	if prefix == "" {
+		return c.Default
+	}
+
+	p := c.Routes[prefix]
+	if p.AllowOrigins == nil {
+		p.AllowOrigins = c.Default.AllowOrigins
+	}
+	if p.AllowMethods == nil {
+		p.AllowMethods = c.Default.AllowMethods
+	}
+	if p.AllowHeaders == nil {
+		p.AllowHeaders = c.Default.AllowHeaders
+	}
+	if p.ExposeHeaders == nil {
+		p.ExposeHeaders = c.Default.ExposeHeaders
+	}
+	if p.MaxAge == 0 {
+		p.MaxAge = c.Default.MaxAge
+	}
+	return p
+}
+
+// longestPrefix は routes のキーのうち path に最も長く一致する接頭辞を返します。一致しない場合は空文字を返します。
+func longestPrefix[V any](routes map[string]V, path string) string {
+	prefix := ""
+	for p := range routes {
+		if matchPrefix(path, p) && len(p) > len(prefix) {
+			prefix = p
+		}
+	}
+	return prefix
+}
+
+// matchPrefix は path がパスの区切りの単位で prefix から始まるかを返します（/v1/admin は /v1/administrator に一致しません）。
+// prefix の * は任意の1区切りに一致します（/v1/surveyors/*/tracks は /v1/surveyors/123/tracks に一致します）。
+func matchPrefix(path, prefix string) bool {
+	prefix = strings.TrimSuffix(prefix, "/")
+	if !strings.Contains(prefix, "*") {
+		return path == prefix || strings.HasPrefix(path, prefix+"/")
+	}
+	ps, ss := strings.Split(prefix, "/"), strings.Split(path, "/")
+	if len(ss) < len(ps) {
+		return false
+	}
+	for i, p := range ps {
+		if p != "*" && p != ss[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// RateLimitConfig はクライアントごとのレート制限の設定です。
+// クライアントは認証済みのユーザー、APIキー、クライアントIPの順で識別します。
+type RateLimitConfig struct {
+	Enabled bool `yaml:"enabled" env:"RATE_LIMIT_ENABLED" reload:"true"`
+	// 全てのルートに適用するポリシー
+	Default RateLimitPolicy `yaml:"default"`
+	// パスの接頭辞（/v1/surveyors など）ごとのポリシー。最も長く一致した接頭辞のポリシーを適用し、接頭辞ごとに別々に計数します。
+	// 指定しなかった項目はデフォルトのポリシーを引き継ぎます（Burstを除く）。
+	Routes map[string]RateLimitPolicy `yaml:"routes" reload:"true"`
+}
+
+// RateLimitPolicy はレート制限のポリシーです（トークンバケット方式）。
+type RateLimitPolicy struct {
+	// Period あたりに許可するリクエスト数
+	Requests int           `yaml:"requests" env:"RATE_LIMIT_REQUESTS" reload:"true"`
+	Period   time.Duration `yaml:"period" env:"RATE_LIMIT_PERIOD" reload:"true"`
+	// 連続して許可するリクエスト数の上限（0の場合はRequestsと同じ）
+	Burst int `yaml:"burst" env:"RATE_LIMIT_BURST" reload:"true"`
+}
+
+// Route は path に最も長く一致するRoutesの接頭辞を返します。一致しない場合は空文字を返します。
+func (c RateLimitConfig) Route(path string) string {
+	return longestPrefix(c.Routes, path)
+}
+
+// Policy は path に適用するポリシーを返します。
+func (c RateLimitConfig) Policy(path string) RateLimitPolicy {
+	prefix := c.Route(path)
+	if prefix == "" {
+		return c.Default
+	}
+
+	p := c.Routes[prefix]
+	if p.Requests == 0 {
+		p.Requests = c.Default.Requests
+	}
+	if p.Period == 0 {
+		p.Period = c.Default.Period
+	}
+	return p
+}
+
+// HTTPCacheConfig はGET/HEADのレスポンスのキャッシュの設定です。
+type HTTPCacheConfig struct {
+	// レスポンスにETagを付与し、If-None-Matchが一致する場合は304を返す
+	ETag bool `yaml:"etag" env:"HTTP_CACHE_ETAG" reload:"true"`
+	// 全てのルートに適用するCache-Controlヘッダーの値
+	CacheControl string `yaml:"cacheControl" env:"HTTP_CACHE_CONTROL" reload:"true"`
+	// パスの接頭辞ごとのCache-Controlヘッダーの値。最も長く一致した接頭辞の値を適用します。
+	// パスパラメーターを含むルートは * で指定します（/v1/surveyors/*/tracks など）。
+	Routes map[string]string `yaml:"routes" reload:"true"`
+}
+
+// CacheControlFor は path に適用するCache-Controlヘッダーの値を返します。
+func (c HTTPCacheConfig) CacheControlFor(path string) string {
+	if prefix := longestPrefix(c.Routes, path); prefix != "" {
+		return c.Routes[prefix]
+	}
+	return c.CacheControl
+}
+
+// CompressionConfig はレスポンスの圧縮の設定です。
+type CompressionConfig struct {
+	Enabled bool `yaml:"enabled" env:"COMPRESSION_ENABLED" reload:"true"`
+	// 使用する圧縮形式 (zstd, br, gzip)。クライアントの優先度が同じ場合は先に指定したものを使用します。
+	Encodings []string `yaml:"encodings" env:"COMPRESSION_ENCODINGS" reload:"true"`
+	// 圧縮するレスポンスの最小サイズ（バイト）
+	MinSize int `yaml:"minSize" env:"COMPRESSION_MIN_SIZE" reload:"true"`
+}
+
+// I18nConfig はレスポンスのメッセージの言語の設定です。
+type I18nConfig struct {
+	// Accept-Languageで対応している言語 (ja, en) が指定されていない場合に使用する言語
+	DefaultLanguage string `yaml:"defaultLanguage" env:"DEFAULT_LANGUAGE" reload:"true"`
+}
+
+// ErrorsConfig はエラーレスポンスの設定です。
+type ErrorsConfig struct {
+	// エラーレスポンスの形式 (json: ErrorResponse, problem: RFC 9457 Problem Details)
+	// Acceptヘッダーで application/problem+json または application/json が指定された場合はそちらを優先します。
+	Format string `yaml:"format" env:"ERROR_FORMAT" reload:"true"`
+	// Problem Detailsのtypeに使用するURIの接頭辞（エラーコードの小文字表記を付与します）
+	ProblemTypeBaseURI string `yaml:"problemTypeBaseURI" env:"ERROR_PROBLEM_TYPE_BASE_URI" reload:"true"`
+}
+
+// EventsConfig は変更通知（Server-Sent Events）の設定です。
+type EventsConfig struct {
+	// 再接続時（Last-Event-ID）に再送するため保持する通知の件数
+	ReplaySize int `yaml:"replaySize" env:"EVENTS_REPLAY_SIZE"`
+	// 接続ごとに送信待ちにできる通知の件数（超えた場合は切断し、再接続時に再送します）
+	BufferSize int `yaml:"bufferSize" env:"EVENTS_BUFFER_SIZE"`
+	// プロキシに接続を切断されないよう、通知がない間にコメントを送信する間隔
+	HeartbeatInterval time.Duration `yaml:"heartbeatInterval" env:"EVENTS_HEARTBEAT_INTERVAL" reload:"true"`
+}
+
+// PositionsConfig は調査員の位置の共有（WebSocket）の設定です。
+type PositionsConfig struct {
+	// 調査員ごとに測位結果を保存する最小の間隔（短い間隔で送信された測位結果は破棄します）
+	MinInterval time.Duration `yaml:"minInterval" env:"POSITIONS_MIN_INTERVAL"`
+	// 最後の測位から位置が更新されていないとみなすまでの時間
+	StaleAfter time.Duration `yaml:"staleAfter" env:"POSITIONS_STALE_AFTER" reload:"true"`
+	// 接続ごとに送信待ちにできる位置の件数（超えた場合は切断します）
+	BufferSize int `yaml:"bufferSize" env:"POSITIONS_BUFFER_SIZE"`
+	// 受信日時より後の測位日時を許容する時間（端末の時計のずれ、超えた測位結果は拒否します）
+	MaxClockSkew time.Duration `yaml:"maxClockSkew" env:"POSITIONS_MAX_CLOCK_SKEW"`
+	// 受信日時より前の測位日時を許容する時間（送信の遅れ、超えた測位結果は拒否します）
+	MaxAge time.Duration `yaml:"maxAge" env:"POSITIONS_MAX_AGE"`
+}
+
+// TracksConfig は調査員の軌跡の設定です。
+type TracksConfig struct {
+	// 軌跡を日付ごとに区切るタイムゾーン（IANAのタイムゾーン名）
+	TimeZone string `yaml:"timeZone" env:"TRACKS_TIME_ZONE"`
+	// 軌跡を保持する期間
+	Retention time.Duration `yaml:"retention" env:"TRACKS_RETENTION"`
+	// 軌跡を簡略化する際に許容する距離（メートル、リクエストで指定されていない場合に使用します）
+	SimplifyTolerance int `yaml:"simplifyTolerance" env:"TRACKS_SIMPLIFY_TOLERANCE" reload:"true"`
+	// 滞在とみなす範囲（メートル）
+	StopRadius int `yaml:"stopRadius" env:"TRACKS_STOP_RADIUS"`
+	// 滞在とみなす最短の時間
+	StopMinDuration time.Duration `yaml:"stopMinDuration" env:"TRACKS_STOP_MIN_DURATION"`
+	// 滞在した位置とお客さまの位置を対応付ける最大の距離（メートル）
+	CustomerMatchRadius int `yaml:"customerMatchRadius" env:"TRACKS_CUSTOMER_MATCH_RADIUS"`
+}
+
+// Location は軌跡を日付ごとに区切るタイムゾーンを返します（不正な場合はUTC）。
+func (c TracksConfig) Location() *time.Location {
+	loc, err := time.LoadLocation(c.TimeZone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// GeofenceConfig は訪問結果を記録した位置の確認の設定です。
+// 記録時の測位結果と前後の軌跡をお客さまの位置と比較し、距離に応じて現地・近隣・遠方に分類します。
+type GeofenceConfig struct {
+	// 全ての事業所に適用するポリシー
+	Default GeofencePolicy `yaml:"default"`
+	// 事業所IDごとのポリシー。指定しなかった項目はデフォルトのポリシーを引き継ぎます。
+	Offices map[string]GeofencePolicy `yaml:"offices" reload:"true"`
+	// 訪問結果の記録日時の前後で軌跡と比較する時間
+	TrackWindow time.Duration `yaml:"trackWindow" env:"GEOFENCE_TRACK_WINDOW" reload:"true"`
+}
+
+// GeofencePolicy は訪問結果を記録した位置を分類する距離（メートル）です。
+type GeofencePolicy struct {
+	// お客さまの位置からこの距離以内で記録された訪問を現地とみなす
+	OnSiteRadius int `yaml:"onSiteRadius" env:"GEOFENCE_ON_SITE_RADIUS" reload:"true"`
+	// お客さまの位置からこの距離以内で記録された訪問を近隣とみなす（超える場合は遠方）
+	NearRadius int `yaml:"nearRadius" env:"GEOFENCE_NEAR_RADIUS" reload:"true"`
+}
+
+// Policy は事業所に適用するポリシーを返します。
+func (c GeofenceConfig) Policy(officeID string) GeofencePolicy {
+	p, ok := c.Offices[officeID]
+	if !ok {
+		return c.Default
+	}
+	if p.OnSiteRadius == 0 {
+		p.OnSiteRadius = c.Default.OnSiteRadius
+	}
+	if p.NearRadius == 0 {
+		p.NearRadius = c.Default.NearRadius
+	}
+	return p
+}
+
+// MetricsConfig はメトリクス公開の設定です。
+type MetricsConfig struct {
+	// メトリクスを別ポートで公開する場合のポート（空の場合はServer.Portで公開）
+	Port string `yaml:"port" env:"METRICS_PORT" flag:"metrics-port"`
+}
+
+// TraceConfig はトレースの設定です。
+type TraceConfig struct {
+	// エクスポーター (none, stdout, file, otlp)
+	Exporter string `yaml:"exporter" env:"TRACE_EXPORTER"`
+	// fileエクスポーターの出力先
+	File string `yaml:"file" env:"TRACE_FILE"`
+	// otlpエクスポーターの送信先URL（空の場合はOTEL_EXPORTER_OTLP_*環境変数に従う）
+	OTLPEndpoint string `yaml:"otlpEndpoint" env:"TRACE_OTLP_ENDPOINT"`
+}
+
+// Options は設定項目以外のコマンドライン引数です。
+type Options struct {
+	// 設定ファイルのパス
+	ConfigFile string
+	// 有効な設定を表示して終了する
+	PrintConfig bool
+}
+
+// Load は設定ファイル・.env ファイル・環境変数・コマンドライン引数から設定を読み込みます。
+// 不正な値がある場合は、全ての不正な値をまとめたエラーを返します。
+func Load(args []string) (Config, Options, error) {
 	var cfg Config
+	var opts Options
 
 	// .envファイルが存在しなくてもエラーにはしません。
 	if err := godotenv.Load(); err != nil {
 		if !os.IsNotExist(err) {
-			return cfg, fmt.Errorf("failed to load .env file: %w", err)
+			return cfg, opts, fmt.Errorf("failed to load .env file: %w", err)
 		}
 	}
 
-	cfg.AllowOrigin = os.Getenv("ALLOW_ORIGIN")
-	if cfg.AllowOrigin == "" {
-		cfg.AllowOrigin = "http://localhost:5173"
+	// コマンドライン引数の定義（設定項目の引数はタグから生成する）
+	fs := flag.NewFlagSet("app", flag.ContinueOnError)
+	fs.StringVar(&opts.ConfigFile, "config", os.Getenv("CONFIG_FILE"), "path to the configuration file (YAML or TOML)")
+	profile := fs.String("profile", os.Getenv("APP_PROFILE"), "configuration profile (dev, staging, production)")
+	fs.BoolVar(&opts.PrintConfig, "print-config", false, "print the effective configuration with secrets masked and exit")
+	overrides := defineFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return cfg, opts, err
 	}
 
-	cfg.Port = os.Getenv("PORT")
-	if cfg.Port == "" {
-		cfg.Port = "8080"
+	if *profile == "" {
+		*profile = ProfileDev
+	}
+	cfg, ok := defaults(*profile)
+	if !ok {
+		return cfg, opts, fmt.Errorf("unknown profile: %q", *profile)
 	}
 
-	return cfg, nil
+	var errs []error
+
+	// 設定ファイル
+	if opts.ConfigFile != "" {
+		if err := loadFile(opts.ConfigFile, &cfg, true); err != nil {
+			errs = append(errs, err)
+		}
+		// プロファイル別の設定ファイル（config.production.yaml など）は存在する場合のみ読み込む
+		if err := loadFile(profileFile(opts.ConfigFile, *profile), &cfg, false); err != nil {
+			errs = append(errs, err)
+		}
+		if cfg.Profile != *profile {
+			errs = append(errs, fmt.Errorf("profile: %q in the configuration file does not match the selected profile %q", cfg.Profile, *profile))
+		}
+	}
+
+	// 環境変数
+	errs = append(errs, applyEnv(&cfg)...)
+
+	// コマンドライン引数
+	errs = append(errs, overrides.apply(&cfg)...)
+
+	// 値の検証
+	errs = append(errs, cfg.Validate()...)
+
+	return cfg, opts, errors.Join(errs...)
+}
+
+// defaults はプロファイルごとのデフォルト値を返します。
+func defaults(profile string) (Config, bool) {
+	cfg := Config{
+		Profile: profile,
+		Server: ServerConfig{
+			Port:              "8080",
+			ReadTimeout:       15 * time.Second,
+			ReadHeaderTimeout: 5 * time.Second,
+			WriteTimeout:      30 * time.Second,
+			IdleTimeout:       60 * time.Second,
+			MaxHeaderBytes:    1 << 20,
+			ShutdownTimeout:   20 * time.Second,
+			RequestTimeout:    10 * time.Second,
+			RouteTimeouts: map[string]time.Duration{
+				// 変更通知のストリーミングと位置の共有（WebSocket）は接続を維持し続ける
+				"/v1/events":                   0,
+				"/v1/surveyors/positions/live": 0,
+			},
+		},
+		TLS: TLSConfig{
+			ReloadInterval:  time.Minute,
+			MinVersion:      "1.2",
+			ClientAuth:      "none",
+			SelfSignedHosts: []string{"localhost", "127.0.0.1", "::1"},
+			HTTP2:           true,
+		},
+		Database: DatabaseConfig{
+			MaxOpenConns:    10,
+			MaxIdleConns:    5,
+			ConnMaxLifetime: 30 * time.Minute,
+		},
+		Cache: CacheConfig{
+			Enabled:     true,
+			Size:        1000,
+			TTL:         time.Minute,
+			LoadTimeout: 5 * time.Second,
+		},
+		Auth: AuthConfig{
+			TokenTTL: time.Hour,
+		},
+		Log: LogConfig{
+			Level:        "info",
+			Format:       "json",
+			RedactParams: []string{"token", "access_token", "password", "secret", "api_key"},
+		},
+		CORS: CORSConfig{
+			Default: CORSPolicy{
+				AllowOrigins:  []string{"http://localhost:5173"},
+				AllowMethods:  []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+				AllowHeaders:  []string{"Origin", "Content-Type", "Authorization", "If-Match", "If-None-Match", "X-Request-ID", "X-API-Key"},
+				ExposeHeaders: []string{"X-Request-ID", "ETag", "Retry-After", "X-RateLimit-Limit", "X-RateLimit-Policy", "X-RateLimit-Remaining", "X-RateLimit-Reset"},
+				MaxAge:        24 * time.Hour,
+			},
+		},
+		RateLimit: RateLimitConfig{
+			Enabled: true,
+			Default: RateLimitPolicy{
+				Requests: 600,
+				Period:   time.Minute,
+				Burst:    100,
+			},
+		},
+		HTTPCache: HTTPCacheConfig{
+			ETag: true,
+			// キャッシュした場合も毎回ETagで再検証させる
+			CacheControl: "no-cache",
+			Routes: map[string]string{
+				// 調査員などの参照データは更新頻度が低いため短時間のキャッシュを許可する
+				"/v1/surveyors": "private, max-age=60, must-revalidate",
+				// 位置と軌跡は常に最新のものを返す
+				"/v1/surveyors/positions": "no-cache",
+				"/v1/surveyors/*/tracks":  "no-cache",
+				"/swagger":                "public, max-age=3600",
+				"/metrics":                "no-store",
+			},
+		},
+		Compression: CompressionConfig{
+			Enabled:   true,
+			Encodings: []string{"zstd", "br", "gzip"},
+			MinSize:   1024,
+		},
+		I18n: I18nConfig{
+			DefaultLanguage: "ja",
+		},
+		Errors: ErrorsConfig{
+			Format:             "json",
+			ProblemTypeBaseURI: "/problems/",
+		},
+		Events: EventsConfig{
+			ReplaySize:        1000,
+			BufferSize:        64,
+			HeartbeatInterval: 15 * time.Second,
+		},
+		Positions: PositionsConfig{
+			MinInterval:  5 * time.Second,
+			StaleAfter:   5 * time.Minute,
+			BufferSize:   256,
+			MaxClockSkew: time.Minute,
+			MaxAge:       time.Hour,
+		},
+		Tracks: TracksConfig{
+			TimeZone:            "Asia/Tokyo",
+			Retention:           90 * 24 * time.Hour,
+			SimplifyTolerance:   5,
+			StopRadius:          30,
+			StopMinDuration:     3 * time.Minute,
+			CustomerMatchRadius: 50,
+		},
+		Geofence: GeofenceConfig{
+			Default: GeofencePolicy{
+				OnSiteRadius: 50,
+				NearRadius:   200,
+			},
+			TrackWindow: 10 * time.Minute,
+		},
+		Trace: TraceConfig{
+			Exporter: "none",
+			File:     "traces.jsonl",
+		},
+		Features: map[string]bool{
+			FeatureSwagger: true,
+		},
+	}
+
+	switch profile {
+	case ProfileDev:
+		cfg.Log.Level = "debug"
+		cfg.Log.Format = "text"
+	case ProfileStaging:
+		// 許可するオリジンは環境ごとに明示的に設定する
+		cfg.CORS.Default.AllowOrigins = nil
+	case ProfileProduction:
+		cfg.CORS.Default.AllowOrigins = nil
+		// APIの仕様は本番環境では公開しない（必要な場合は features.swagger で有効にする）
+		cfg.Features[FeatureSwagger] = false
+		cfg.TLS.MinVersion = "1.3"
+		cfg.Database.MaxOpenConns = 50
+		cfg.Database.MaxIdleConns = 25
+	default:
+		return cfg, false
+	}
+	return cfg, true
 }
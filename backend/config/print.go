@@ -0,0 +1,42 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// マスク後の値
+const maskedValue = "********"
+
+// Masked はsecretタグが設定された項目をマスクした設定を返します。
+func (c Config) Masked() Config {
+	walk(&c, func(f reflect.StructField, v reflect.Value, path string) {
+		if f.Tag.Get("secret") != "true" || v.IsZero() {
+			return
+		}
+		switch v.Kind() {
+		case reflect.String:
+			v.SetString(maskedValue)
+		case reflect.Slice:
+			masked := make([]string, v.Len())
+			for i := range masked {
+				masked[i] = maskedValue
+			}
+			v.Set(reflect.ValueOf(masked))
+		}
+	})
+	return c
+}
+
+// String は秘密情報をマスクした設定をYAML形式で返します。
+func (c Config) String() string {
+	var sb strings.Builder
+	enc := yaml.NewEncoder(&sb)
+	enc.SetIndent(2)
+	if err := enc.Encode(c.Masked()); err != nil {
+		return err.Error()
+	}
+	return sb.String()
+}
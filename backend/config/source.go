@@ -0,0 +1,180 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+	"go.yaml.in/yaml/v3"
+)
+
+// loadFile は設定ファイルを読み込み、cfg に上書きします。
+// 拡張子が .toml の場合はTOML、それ以外はYAMLとして扱います。
+func loadFile(path string, cfg *Config, required bool) error {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		if !required && errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return fmt.Errorf("failed to read configuration file: %w", err)
+	}
+
+	// TOMLは一度汎用の値に変換し、YAMLと同じ規則（time.Durationの文字列表記など）でデコードする
+	if strings.EqualFold(filepath.Ext(path), ".toml") {
+		var m map[string]any
+		if err := toml.Unmarshal(b, &m); err != nil {
+			return fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		if b, err = yaml.Marshal(m); err != nil {
+			return fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+	}
+
+	dec := yaml.NewDecoder(bytes.NewReader(b))
+	// 設定項目名の誤りに気付けるよう、未知の項目はエラーにする
+	dec.KnownFields(true)
+	if err := dec.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return nil
+}
+
+// profileFile はプロファイル別の設定ファイルのパスを返します（config.yaml → config.production.yaml）。
+func profileFile(path, profile string) string {
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + "." + profile + ext
+}
+
+// applyEnv はenvタグが設定された項目を環境変数で上書きします。
+func applyEnv(cfg *Config) []error {
+	var errs []error
+	walk(cfg, func(f reflect.StructField, v reflect.Value, path string) {
+		key := f.Tag.Get("env")
+		if key == "" {
+			return
+		}
+		s, ok := os.LookupEnv(key)
+		if !ok || s == "" {
+			return
+		}
+		if err := setValue(v, s); err != nil {
+			errs = append(errs, fmt.Errorf("%s (env %s): %w", path, key, err))
+		}
+	})
+	return errs
+}
+
+// flagOverrides はコマンドライン引数で指定された値です。
+type flagOverrides map[string]*flagValue
+
+// flagValue はコマンドライン引数の値を文字列のまま保持します。
+// bool型の項目は値を省略して指定できます（-tls-self-signed は -tls-self-signed=true と同じ）。
+type flagValue struct {
+	value  string
+	isBool bool
+}
+
+func (v *flagValue) String() string     { return v.value }
+func (v *flagValue) Set(s string) error { v.value = s; return nil }
+func (v *flagValue) IsBoolFlag() bool   { return v.isBool }
+
+// defineFlags はflagタグが設定された項目のコマンドライン引数を定義します。
+// 指定された値はパース後に apply で設定に反映します。
+func defineFlags(fs *flag.FlagSet) flagOverrides {
+	o := flagOverrides{}
+	walk(&Config{}, func(f reflect.StructField, v reflect.Value, path string) {
+		name := f.Tag.Get("flag")
+		if name == "" {
+			return
+		}
+		o[path] = &flagValue{isBool: v.Kind() == reflect.Bool}
+		fs.Var(o[path], name, fmt.Sprintf("overrides %s", path))
+	})
+	return o
+}
+
+// apply は指定されたコマンドライン引数で設定を上書きします。
+func (o flagOverrides) apply(cfg *Config) []error {
+	var errs []error
+	walk(cfg, func(f reflect.StructField, v reflect.Value, path string) {
+		s, ok := o[path]
+		if !ok || s.value == "" {
+			return
+		}
+		if err := setValue(v, s.value); err != nil {
+			errs = append(errs, fmt.Errorf("%s (flag -%s): %w", path, f.Tag.Get("flag"), err))
+		}
+	})
+	return errs
+}
+
+// walk は設定の全ての項目について、yamlタグから組み立てたパス（server.port など）とともに fn を呼び出します。
+func walk(cfg *Config, fn func(f reflect.StructField, v reflect.Value, path string)) {
+	var rec func(v reflect.Value, prefix string)
+	rec = func(v reflect.Value, prefix string) {
+		t := v.Type()
+		for i := range t.NumField() {
+			f := t.Field(i)
+			path := prefix + strings.Split(f.Tag.Get("yaml"), ",")[0]
+			if f.Type.Kind() == reflect.Struct {
+				rec(v.Field(i), path+".")
+				continue
+			}
+			fn(f, v.Field(i), path)
+		}
+	}
+	rec(reflect.ValueOf(cfg).Elem(), "")
+}
+
+var durationType = reflect.TypeFor[time.Duration]()
+
+// setValue は文字列を項目の型に変換して設定します。スライスはカンマ区切りとして扱います。
+func setValue(v reflect.Value, s string) error {
+	switch {
+	case v.Type() == durationType:
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(d))
+	case v.Kind() == reflect.String:
+		v.SetString(s)
+	case v.Kind() == reflect.Int:
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(n))
+	case v.Kind() == reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.String:
+		v.Set(reflect.ValueOf(splitList(s)))
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+	return nil
+}
+
+// splitList はカンマ区切りの文字列を空要素を除いたスライスに変換します。
+func splitList(s string) []string {
+	var ret []string
+	for v := range strings.SplitSeq(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			ret = append(ret, v)
+		}
+	}
+	return ret
+}
@@ -0,0 +1,188 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"reflect"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Change は再読み込みで変更された設定項目です。
+type Change struct {
+	Path string
+	Old  string
+	New  string
+}
+
+func (c Change) String() string {
+	return fmt.Sprintf("%s: %s -> %s", c.Path, c.Old, c.New)
+}
+
+// Store は実行中の設定を保持し、再読み込みを行います。
+//
+// reloadタグが設定された項目のみ再読み込みで変更できます。
+// それ以外の項目（ポートなど）が変更されている場合は再読み込み全体を拒否し、現在の設定を維持します。
+type Store struct {
+	args    []string
+	opts    Options
+	current atomic.Pointer[Config]
+
+	mu          sync.Mutex
+	subscribers []func(Config)
+}
+
+// NewStore は読み込み済みの設定を保持するStoreを生成します。
+// args は再読み込み時に Load に渡すコマンドライン引数です。
+func NewStore(cfg Config, opts Options, args []string) *Store {
+	s := &Store{args: args, opts: opts}
+	s.current.Store(&cfg)
+	return s
+}
+
+// Current は現在の設定を返します。
+func (s *Store) Current() Config {
+	return *s.current.Load()
+}
+
+// Subscribe は設定が変更された際に呼び出す関数を登録します。
+func (s *Store) Subscribe(fn func(Config)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.subscribers = append(s.subscribers, fn)
+}
+
+// Reload は設定を読み込み直し、変更可能な項目のみが変更されている場合に設定を差し替えます。
+func (s *Store) Reload() ([]Change, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	next, _, err := Load(s.args)
+	if err != nil {
+		return nil, err
+	}
+
+	old := s.Current()
+	changes := diff(old, next)
+
+	var rejected []string
+	for _, c := range changes {
+		if !reloadable[c.Path] {
+			rejected = append(rejected, c.Path)
+		}
+	}
+	if len(rejected) > 0 {
+		return changes, fmt.Errorf("restart required to change: %v", rejected)
+	}
+	if len(changes) == 0 {
+		return nil, nil
+	}
+
+	s.current.Store(&next)
+	for _, fn := range s.subscribers {
+		fn(next)
+	}
+	return changes, nil
+}
+
+// Watch は設定ファイルの更新、または trigger の受信（SIGHUPなど）を契機に設定を再読み込みします。
+// ctx がキャンセルされるまで戻りません。
+func (s *Store) Watch(ctx context.Context, interval time.Duration, trigger <-chan os.Signal) {
+	var files []string
+	if s.opts.ConfigFile != "" {
+		files = []string{s.opts.ConfigFile, profileFile(s.opts.ConfigFile, s.Current().Profile)}
+	}
+	last := modTimes(files)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case sig := <-trigger:
+			slog.Info("reloading configuration", "trigger", sig.String())
+		case <-ticker.C:
+			mt := modTimes(files)
+			if slices.Equal(mt, last) {
+				continue
+			}
+			last = mt
+			slog.Info("reloading configuration", "trigger", "file changed")
+		}
+
+		changes, err := s.Reload()
+		if err != nil {
+			slog.Error("configuration reload rejected", "error", err, "changes", changes)
+			continue
+		}
+		if len(changes) == 0 {
+			slog.Info("configuration unchanged")
+			continue
+		}
+		slog.Info("configuration reloaded", "changes", changes)
+	}
+}
+
+// modTimes はファイルの更新日時を返します（存在しないファイルはゼロ値）。
+func modTimes(files []string) []time.Time {
+	ret := make([]time.Time, len(files))
+	for i, f := range files {
+		if fi, err := os.Stat(f); err == nil {
+			ret[i] = fi.ModTime()
+		} else if !errors.Is(err, os.ErrNotExist) {
+			slog.Warn("failed to stat configuration file", "file", f, "error", err)
+		}
+	}
+	return ret
+}
+
+// reloadable は再読み込みで変更できる項目のパスです。
+var reloadable = func() map[string]bool {
+	m := map[string]bool{}
+	walk(&Config{}, func(f reflect.StructField, v reflect.Value, path string) {
+		if f.Tag.Get("reload") == "true" {
+			m[path] = true
+		}
+	})
+	return m
+}()
+
+// diff は2つの設定の差分を返します。秘密情報はマスクした値で比較結果を表します。
+func diff(a, b Config) []Change {
+	av, bv := values(a), values(b)
+	var changes []Change
+	for i := range av {
+		if av[i].value != bv[i].value || av[i].raw != bv[i].raw {
+			changes = append(changes, Change{Path: av[i].path, Old: av[i].value, New: bv[i].value})
+		}
+	}
+	return changes
+}
+
+type fieldValue struct {
+	path  string
+	value string
+	raw   string
+}
+
+// values は全項目の表示用の値（秘密情報はマスク済み）と比較用の値を返します。
+func values(c Config) []fieldValue {
+	var ret []fieldValue
+	walk(&c, func(f reflect.StructField, v reflect.Value, path string) {
+		ret = append(ret, fieldValue{path: path, raw: fmt.Sprint(v.Interface())})
+	})
+	m := c.Masked()
+	i := 0
+	walk(&m, func(f reflect.StructField, v reflect.Value, path string) {
+		ret[i].value = fmt.Sprint(v.Interface())
+		i++
+	})
+	return ret
+}
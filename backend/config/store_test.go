@@ -0,0 +1,97 @@
+package config
+
+import (
+	"context"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Store_Reload(t *testing.T) {
+	file := writeFile(t, t.TempDir(), "config.yaml", "log:\n  level: info\n")
+	args := []string{"-config", file}
+
+	cfg, opts, err := Load(args)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := NewStore(cfg, opts, args)
+
+	var notified []string
+	s.Subscribe(func(c Config) { notified = append(notified, c.Log.Level) })
+
+	assert := assert.New(t)
+
+	// 変更なし
+	changes, err := s.Reload()
+	assert.NoError(err)
+	assert.Empty(changes)
+
+	// 再読み込み可能な項目の変更は反映される
+	rewriteFile(t, file, "log:\n  level: warn\ncors:\n  default:\n    allowOrigins: [https://a.example.com]\nfeatures:\n  sse: true\n")
+	changes, err = s.Reload()
+	if assert.NoError(err) {
+		paths := []string{}
+		for _, c := range changes {
+			paths = append(paths, c.Path)
+		}
+		assert.ElementsMatch([]string{"log.level", "cors.default.allowOrigins", "features"}, paths)
+		assert.Equal("warn", s.Current().Log.Level)
+		assert.True(s.Current().FeatureEnabled("sse"))
+		assert.Equal([]string{"warn"}, notified)
+	}
+
+	// 再読み込みできない項目が含まれる場合は全体を拒否する
+	rewriteFile(t, file, "log:\n  level: error\nserver:\n  port: \"9999\"\n")
+	_, err = s.Reload()
+	if assert.ErrorContains(err, "server.port") {
+		assert.Equal("warn", s.Current().Log.Level)
+		assert.Equal([]string{"warn"}, notified)
+	}
+
+	// 不正な値の場合も拒否する
+	rewriteFile(t, file, "log:\n  level: verbose\n")
+	_, err = s.Reload()
+	assert.ErrorContains(err, "log.level")
+	assert.Equal("warn", s.Current().Log.Level)
+}
+
+func Test_Store_Watch(t *testing.T) {
+	file := writeFile(t, t.TempDir(), "config.yaml", "log:\n  level: info\n")
+	args := []string{"-config", file}
+
+	cfg, opts, err := Load(args)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := NewStore(cfg, opts, args)
+
+	changed := make(chan Config, 1)
+	s.Subscribe(func(c Config) { changed <- c })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	trigger := make(chan os.Signal, 1)
+	// 更新日時による検知を待たずにシグナルで再読み込みさせる
+	go s.Watch(ctx, time.Hour, trigger)
+
+	rewriteFile(t, file, "log:\n  level: debug\n")
+	trigger <- syscall.SIGHUP
+
+	select {
+	case c := <-changed:
+		assert.Equal(t, "debug", c.Log.Level)
+	case <-time.After(time.Second):
+		assert.Fail(t, "configuration was not reloaded")
+	}
+}
+
+func rewriteFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
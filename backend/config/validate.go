@@ -0,0 +1,313 @@
+package config
+
+import (
+	"fmt"
+	"maps"
+	"net/netip"
+	"net/url"
+	"react-ts/backend/internal/i18n"
+	"react-ts/backend/internal/logging"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Validate は設定値を検証し、不正な値を全て返します。
+func (c Config) Validate() []error {
+	var errs []error
+	add := func(path string, format string, args ...any) {
+		errs = append(errs, fmt.Errorf("%s: %s", path, fmt.Sprintf(format, args...)))
+	}
+
+	// server
+	validatePort := func(path, port string, allowEmpty bool) {
+		if port == "" && allowEmpty {
+			return
+		}
+		if n, err := strconv.Atoi(port); err != nil || n < 1 || n > 65535 {
+			add(path, "must be a port number between 1 and 65535, got %q", port)
+		}
+	}
+	validatePort("server.port", c.Server.Port, false)
+	for _, d := range []struct {
+		path string
+		v    time.Duration
+	}{
+		{"server.readTimeout", c.Server.ReadTimeout},
+		{"server.readHeaderTimeout", c.Server.ReadHeaderTimeout},
+		{"server.writeTimeout", c.Server.WriteTimeout},
+		{"server.idleTimeout", c.Server.IdleTimeout},
+		{"server.shutdownTimeout", c.Server.ShutdownTimeout},
+	} {
+		if d.v <= 0 {
+			add(d.path, "must be positive")
+		}
+	}
+	if c.Server.MaxHeaderBytes <= 0 {
+		add("server.maxHeaderBytes", "must be positive")
+	}
+	validateRequestTimeout := func(path string, d time.Duration, allowZero bool) {
+		if d < 0 || (d == 0 && !allowZero) {
+			add(path, "must be positive")
+		}
+		// 処理時間の上限に達した際のエラーレスポンスを書き込む前に接続が切断されないようにする
+		if d > 0 && c.Server.WriteTimeout > 0 && d >= c.Server.WriteTimeout {
+			add(path, "must be less than server.writeTimeout (%s)", c.Server.WriteTimeout)
+		}
+	}
+	validateRequestTimeout("server.requestTimeout", c.Server.RequestTimeout, false)
+	for _, prefix := range slices.Sorted(maps.Keys(c.Server.RouteTimeouts)) {
+		if !strings.HasPrefix(prefix, "/") {
+			add("server.routeTimeouts", "path prefix must start with /, got %q", prefix)
+		}
+		validateRequestTimeout(fmt.Sprintf("server.routeTimeouts[%s]", prefix), c.Server.RouteTimeouts[prefix], true)
+	}
+	for _, proxy := range c.Server.TrustedProxies {
+		if _, err := netip.ParsePrefix(proxy); err != nil {
+			if _, err := netip.ParseAddr(proxy); err != nil {
+				add("server.trustedProxies", "must be an IP address or CIDR, got %q", proxy)
+			}
+		}
+	}
+
+	// tls
+	if (c.TLS.CertFile == "") != (c.TLS.KeyFile == "") {
+		add("tls.certFile", "tls.certFile and tls.keyFile must be specified together")
+	}
+	if c.TLS.SelfSigned && c.TLS.CertFile != "" {
+		add("tls.selfSigned", "must not be combined with tls.certFile")
+	}
+	if c.TLS.SelfSigned && c.Profile == ProfileProduction {
+		add("tls.selfSigned", "must not be used in production")
+	}
+	if c.TLS.ReloadInterval <= 0 {
+		add("tls.reloadInterval", "must be positive")
+	}
+	if !slices.Contains([]string{"1.2", "1.3"}, c.TLS.MinVersion) {
+		add("tls.minVersion", "must be 1.2 or 1.3, got %q", c.TLS.MinVersion)
+	}
+	if !slices.Contains([]string{"none", "optional", "require"}, c.TLS.ClientAuth) {
+		add("tls.clientAuth", "must be one of none, optional, require, got %q", c.TLS.ClientAuth)
+	}
+	if c.TLS.ClientAuth != "none" && c.TLS.ClientCAFile == "" {
+		add("tls.clientCAFile", "is required when tls.clientAuth is %s", c.TLS.ClientAuth)
+	}
+	if c.TLS.HTTP3 && !c.TLS.Enabled() {
+		add("tls.http3", "requires TLS (tls.certFile/tls.keyFile or tls.selfSigned)")
+	}
+
+	// database
+	if c.Database.MaxOpenConns < 0 {
+		add("database.maxOpenConns", "must not be negative")
+	}
+	if c.Database.MaxIdleConns < 0 || c.Database.MaxIdleConns > c.Database.MaxOpenConns {
+		add("database.maxIdleConns", "must be between 0 and database.maxOpenConns (%d)", c.Database.MaxOpenConns)
+	}
+
+	// cache
+	if c.Cache.Enabled {
+		if c.Cache.Size <= 0 {
+			add("cache.size", "must be positive when cache is enabled")
+		}
+		if c.Cache.TTL <= 0 {
+			add("cache.ttl", "must be positive when cache is enabled")
+		}
+		if c.Cache.LoadTimeout <= 0 {
+			add("cache.loadTimeout", "must be positive when cache is enabled")
+		}
+	}
+
+	// auth
+	if c.Auth.TokenTTL <= 0 {
+		add("auth.tokenTTL", "must be positive")
+	}
+	if c.Profile == ProfileProduction && c.Auth.JWTSecret != "" && len(c.Auth.JWTSecret) < 32 {
+		add("auth.jwtSecret", "must be at least 32 characters in production")
+	}
+
+	// log
+	if _, err := logging.ParseLevel(c.Log.Level); err != nil {
+		add("log.level", "must be one of debug, info, warn, error, got %q", c.Log.Level)
+	}
+	if !slices.Contains([]string{"json", "text"}, c.Log.Format) {
+		add("log.format", "must be json or text, got %q", c.Log.Format)
+	}
+
+	// cors
+	validateCORS := func(path string, p CORSPolicy) {
+		for i, o := range p.AllowOrigins {
+			if !validOrigin(o) {
+				add(fmt.Sprintf("%s.allowOrigins[%d]", path, i), "must be an origin such as https://example.com or https://*.example.com, got %q", o)
+			}
+			if o == "*" && p.AllowCredentials {
+				add(fmt.Sprintf("%s.allowOrigins[%d]", path, i), "must not be * when allowCredentials is true")
+			}
+		}
+		if p.MaxAge < 0 {
+			add(path+".maxAge", "must not be negative")
+		}
+	}
+	validateCORS("cors.default", c.CORS.Default)
+	for _, prefix := range slices.Sorted(maps.Keys(c.CORS.Routes)) {
+		if !strings.HasPrefix(prefix, "/") {
+			add("cors.routes", "path prefix must start with /, got %q", prefix)
+		}
+		validateCORS(fmt.Sprintf("cors.routes[%s]", prefix), c.CORS.Policy(prefix))
+	}
+
+	// rateLimit
+	validateRateLimit := func(path string, p RateLimitPolicy) {
+		if p.Requests <= 0 {
+			add(path+".requests", "must be positive")
+		}
+		if p.Period <= 0 {
+			add(path+".period", "must be positive")
+		}
+		if p.Burst < 0 {
+			add(path+".burst", "must not be negative")
+		}
+	}
+	validateRateLimit("rateLimit.default", c.RateLimit.Default)
+	for _, prefix := range slices.Sorted(maps.Keys(c.RateLimit.Routes)) {
+		if !strings.HasPrefix(prefix, "/") {
+			add("rateLimit.routes", "path prefix must start with /, got %q", prefix)
+		}
+		validateRateLimit(fmt.Sprintf("rateLimit.routes[%s]", prefix), c.RateLimit.Policy(prefix))
+	}
+
+	// httpCache
+	for _, prefix := range slices.Sorted(maps.Keys(c.HTTPCache.Routes)) {
+		if !strings.HasPrefix(prefix, "/") {
+			add("httpCache.routes", "path prefix must start with /, got %q", prefix)
+		}
+	}
+
+	// compression
+	for i, e := range c.Compression.Encodings {
+		if !slices.Contains([]string{"zstd", "br", "gzip"}, e) {
+			add(fmt.Sprintf("compression.encodings[%d]", i), "must be one of zstd, br, gzip, got %q", e)
+		}
+	}
+	if c.Compression.Enabled && len(c.Compression.Encodings) == 0 {
+		add("compression.encodings", "must not be empty when compression is enabled")
+	}
+	if c.Compression.MinSize < 0 {
+		add("compression.minSize", "must not be negative")
+	}
+
+	// i18n
+	if !i18n.Valid(c.I18n.DefaultLanguage) {
+		add("i18n.defaultLanguage", "must be one of %v, got %q", i18n.Supported, c.I18n.DefaultLanguage)
+	}
+
+	// errors
+	if !slices.Contains([]string{"json", "problem"}, c.Errors.Format) {
+		add("errors.format", "must be json or problem, got %q", c.Errors.Format)
+	}
+	if _, err := url.Parse(c.Errors.ProblemTypeBaseURI); err != nil {
+		add("errors.problemTypeBaseURI", "must be a URI reference: %v", err)
+	}
+
+	// events
+	if c.Events.ReplaySize < 0 {
+		add("events.replaySize", "must not be negative")
+	}
+	if c.Events.BufferSize <= 0 {
+		add("events.bufferSize", "must be positive")
+	}
+	if c.Events.HeartbeatInterval <= 0 {
+		add("events.heartbeatInterval", "must be positive")
+	}
+
+	// positions
+	if c.Positions.MinInterval < 0 {
+		add("positions.minInterval", "must not be negative")
+	}
+	if c.Positions.StaleAfter <= 0 {
+		add("positions.staleAfter", "must be positive")
+	}
+	if c.Positions.BufferSize <= 0 {
+		add("positions.bufferSize", "must be positive")
+	}
+	if c.Positions.MaxClockSkew < 0 {
+		add("positions.maxClockSkew", "must not be negative")
+	}
+	if c.Positions.MaxAge <= 0 {
+		add("positions.maxAge", "must be positive")
+	}
+
+	// tracks
+	if _, err := time.LoadLocation(c.Tracks.TimeZone); err != nil || c.Tracks.TimeZone == "" {
+		add("tracks.timeZone", "must be an IANA time zone name such as Asia/Tokyo, got %q", c.Tracks.TimeZone)
+	}
+	if c.Tracks.Retention <= 0 {
+		add("tracks.retention", "must be positive")
+	}
+	if c.Tracks.SimplifyTolerance < 0 {
+		add("tracks.simplifyTolerance", "must not be negative")
+	}
+	if c.Tracks.StopRadius <= 0 {
+		add("tracks.stopRadius", "must be positive")
+	}
+	if c.Tracks.StopMinDuration <= 0 {
+		add("tracks.stopMinDuration", "must be positive")
+	}
+	if c.Tracks.CustomerMatchRadius < 0 {
+		add("tracks.customerMatchRadius", "must not be negative")
+	}
+
+	// geofence
+	validateGeofence := func(path string, p GeofencePolicy) {
+		if p.OnSiteRadius <= 0 {
+			add(path+".onSiteRadius", "must be positive")
+		}
+		if p.NearRadius < p.OnSiteRadius {
+			add(path+".nearRadius", "must not be less than onSiteRadius (%d)", p.OnSiteRadius)
+		}
+	}
+	validateGeofence("geofence.default", c.Geofence.Default)
+	for _, officeID := range slices.Sorted(maps.Keys(c.Geofence.Offices)) {
+		validateGeofence(fmt.Sprintf("geofence.offices[%s]", officeID), c.Geofence.Policy(officeID))
+	}
+	if c.Geofence.TrackWindow < 0 {
+		add("geofence.trackWindow", "must not be negative")
+	}
+
+	// metrics
+	validatePort("metrics.port", c.Metrics.Port, true)
+	if c.Metrics.Port != "" && c.Metrics.Port == c.Server.Port {
+		add("metrics.port", "must differ from server.port")
+	}
+
+	// trace
+	if !slices.Contains([]string{"none", "stdout", "file", "otlp"}, c.Trace.Exporter) {
+		add("trace.exporter", "must be one of none, stdout, file, otlp, got %q", c.Trace.Exporter)
+	}
+	if c.Trace.Exporter == "file" && c.Trace.File == "" {
+		add("trace.file", "is required when trace.exporter is file")
+	}
+	if c.Trace.OTLPEndpoint != "" {
+		if u, err := url.Parse(c.Trace.OTLPEndpoint); err != nil || u.Scheme == "" || u.Host == "" {
+			add("trace.otlpEndpoint", "must be a URL, got %q", c.Trace.OTLPEndpoint)
+		}
+	}
+
+	return errs
+}
+
+// validOrigin はオリジンとして正しい形式か（* およびサブドメインのワイルドカードを含む）を返します。
+func validOrigin(o string) bool {
+	if o == "*" {
+		return true
+	}
+	// ワイルドカードはホストの先頭のラベルとしてのみ許可する
+	if i := strings.Index(o, "*"); i >= 0 {
+		if strings.Count(o, "*") > 1 || !strings.HasSuffix(o[:i], "://") || !strings.HasPrefix(o[i+1:], ".") {
+			return false
+		}
+		o = strings.Replace(o, "*", "x", 1)
+	}
+	u, err := url.Parse(o)
+	return err == nil && u.Scheme != "" && u.Host != "" && (u.Path == "" || u.Path == "/") && u.RawQuery == ""
+}
@@ -15,6 +15,195 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/customers/{id}/location": {
+            "put": {
+                "description": "移動元と移動先の作業区の事業所に /events の customer.moved で通知します。",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "customers"
+                ],
+                "summary": "お客さまの位置・作業区を変更する",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "お客さまID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "変更後の位置・作業区",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.PutCustomerLocationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "変更済み"
+                    },
+                    "400": {
+                        "description": "リクエスト形式不正",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "お客さままたは作業区が存在しない",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "リクエスト数の上限超過",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "想定外のエラー",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "処理時間の上限超過",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/error-codes": {
+            "get": {
+                "description": "エラーレスポンスのcodeの一覧です。メッセージはAccept-Languageの言語で返します",
+                "tags": [
+                    "error-codes"
+                ],
+                "summary": "エラーコードの一覧を返す",
+                "responses": {
+                    "200": {
+                        "description": "エラーコードの一覧",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handler.GetErrorCodesResponse"
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "リクエスト数の上限超過",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/events": {
+            "get": {
+                "description": "各通知の event は通知の種類、id は通番、data は EventResponse のJSONです。\n通知は PUT /work-zones/{id}/surveyor（zone.assigned）、PUT /customers/{id}/location（customer.moved）、POST /visits（visit.recorded）の更新後に送信します。\n再接続時に Last-Event-ID ヘッダーを指定すると、それ以降の通知を再送します。\n再送すべき通知が既に破棄されている場合は event が reset の通知を送信するため、データを取得し直してください。\n通知がない間は接続を維持するためにコメント行を送信します。",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "events"
+                ],
+                "summary": "作業区の担当・お客さまの位置・訪問結果の変更をServer-Sent Eventsで通知する",
+                "parameters": [
+                    {
+                        "maxLength": 2,
+                        "type": "string",
+                        "example": "XX",
+                        "description": "TODO 認証を導入したら利用者の所属事業所で絞り込む",
+                        "name": "office-id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "最後に受信した通知のid",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "変更通知のストリーム",
+                        "schema": {
+                            "$ref": "#/definitions/handler.EventResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエスト形式不正",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "リクエスト数の上限超過",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "想定外のエラー",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "サーバーの停止中",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/offices": {
+            "get": {
+                "tags": [
+                    "offices"
+                ],
+                "summary": "事業所のリストを返す",
+                "responses": {
+                    "200": {
+                        "description": "事業所のリスト",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handler.GetOfficesResponse"
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "リクエスト数の上限超過",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "想定外のエラー",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "処理時間の上限超過",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/samples": {
             "get": {
                 "tags": [
@@ -73,11 +262,23 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
+                    "429": {
+                        "description": "リクエスト数の上限超過",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "想定外のエラー",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
+                    },
+                    "504": {
+                        "description": "処理時間の上限超過",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
                     }
                 }
             }
@@ -107,70 +308,1158 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "304": {
+                        "description": "変更なし（If-None-Matchが一致）"
+                    },
                     "400": {
                         "description": "リクエスト形式不正",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
+                    "429": {
+                        "description": "リクエスト数の上限超過",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "想定外のエラー",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
-                    }
-                }
-            }
-        }
-    },
-    "definitions": {
-        "handler.ErrorResponse": {
-            "type": "object",
-            "properties": {
-                "code": {
-                    "type": "string",
-                    "example": "INVALID_REQUEST"
-                },
-                "details": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
                     },
-                    "example": [
-                        "aaa",
-                        "bbb",
-                        "ccc"
-                    ]
-                },
-                "message": {
-                    "type": "string",
-                    "example": "不正なリクエストです"
+                    "504": {
+                        "description": "処理時間の上限超過",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
                 }
             }
         },
-        "handler.GetSampleResponse": {
-            "type": "object",
-            "properties": {
-                "id": {
-                    "type": "string",
-                    "example": "11111"
-                },
-                "name": {
-                    "type": "string",
-                    "example": "サンプル1"
+        "/surveyors/positions": {
+            "get": {
+                "description": "リアルタイムに位置を受け取る場合は /surveyors/positions/live（WebSocket）を使用してください。",
+                "produces": [
+                    "application/geo+json"
+                ],
+                "tags": [
+                    "surveyors"
+                ],
+                "summary": "調査員ごとの最新の位置をGeoJSONで返す",
+                "parameters": [
+                    {
+                        "maxLength": 2,
+                        "type": "string",
+                        "example": "XX",
+                        "name": "office-id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "調査員の最新の位置",
+                        "schema": {
+                            "$ref": "#/definitions/handler.SurveyorPositionsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエスト形式不正",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "リクエスト数の上限超過",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "想定外のエラー",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "処理時間の上限超過",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
                 }
             }
         },
-        "handler.GetSurveyorsResponse": {
-            "type": "object",
-            "properties": {
-                "id": {
-                    "type": "string",
-                    "example": "000001"
-                },
-                "name": {
-                    "type": "string",
-                    "example": "調査員1"
+        "/surveyors/positions/live": {
+            "get": {
+                "description": "surveyor-id を指定した場合は調査員のアプリの接続として、PositionReportRequest のJSONを定期的に受信して最新の位置として保存します。\n短い間隔で送信された測位結果は破棄します。不正な測位結果を受信した場合は event が error のメッセージを返します。\nsurveyor-id を指定しない場合は事業所の調査員の位置を受信する接続として、PositionMessage のJSONを送信します。\n接続時に snapshot、位置の更新ごとに position、位置が一定時間更新されない調査員について stale を送信します。",
+                "tags": [
+                    "surveyors"
+                ],
+                "summary": "調査員の位置をWebSocketで共有する",
+                "parameters": [
+                    {
+                        "maxLength": 2,
+                        "type": "string",
+                        "example": "XX",
+                        "description": "TODO 認証を導入したら利用者の所属事業所・調査員IDを使用する",
+                        "name": "office-id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "maxLength": 6,
+                        "type": "string",
+                        "example": "000001",
+                        "description": "調査員のアプリが測位結果を送信する場合に指定する（指定しない場合は事業所の調査員の位置を受信する）",
+                        "name": "surveyor-id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "101": {
+                        "description": "WebSocketへの切り替え",
+                        "schema": {
+                            "$ref": "#/definitions/handler.PositionMessage"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエスト形式不正",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "許可されていないオリジン",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "426": {
+                        "description": "WebSocketのハンドシェイクではない",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "429": {
+                        "description": "リクエスト数の上限超過",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/surveyors/{id}/tracks": {
+            "get": {
+                "description": "軌跡はDouglas–Peuckerのアルゴリズムで簡略化したLineStringで、各点の測位の日時を properties.coordTimes に含みます。\n一定の範囲に一定時間以上とどまっていた滞在を検出し、近くの担当のお客さまと対応付けて properties.stops に含みます。",
+                "produces": [
+                    "application/geo+json"
+                ],
+                "tags": [
+                    "surveyors"
+                ],
+                "summary": "調査員の1日の軌跡をGeoJSONで返す",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "調査員ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "2026-10-19",
+                        "description": "軌跡の日付（YYYY-MM-DD）",
+                        "name": "date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "maximum": 1000,
+                        "minimum": 0,
+                        "type": "integer",
+                        "example": 5,
+                        "description": "軌跡を簡略化する際に許容する距離（メートル、0の場合は簡略化しない）。省略時は設定値",
+                        "name": "tolerance",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "調査員の軌跡",
+                        "schema": {
+                            "$ref": "#/definitions/handler.SurveyorTrackResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエスト形式不正",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "リクエスト数の上限超過",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "想定外のエラー",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "処理時間の上限超過",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/visits": {
+            "post": {
+                "description": "お客さまの訪問状況を更新し、事業所に /events の visit.recorded で通知します。",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "visits"
+                ],
+                "summary": "お客さまへの訪問結果を記録する",
+                "parameters": [
+                    {
+                        "description": "訪問結果",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.PostVisitRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "記録済み"
+                    },
+                    "400": {
+                        "description": "リクエスト形式不正",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "リクエスト数の上限超過",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "想定外のエラー",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "処理時間の上限超過",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/visits/anomalies": {
+            "get": {
+                "description": "訪問結果を記録した時点の測位結果と前後の軌跡をお客さまの位置と比較し、事業所ごとの距離の条件で現地（ON_SITE）・近隣（NEAR）・遠方（FAR）・確認不可（NO_FIX）に分類します。",
+                "tags": [
+                    "visits"
+                ],
+                "summary": "お客さまの位置から離れて記録された訪問結果のリストを返す",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "2026-10-19",
+                        "description": "訪問結果を記録した日付（YYYY-MM-DD）",
+                        "name": "date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "enum": [
+                                "ON_SITE",
+                                "NEAR",
+                                "FAR",
+                                "NO_FIX"
+                            ],
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "FAR"
+                        ],
+                        "description": "対象とする分類（複数指定可、省略時は ON_SITE 以外の全て）",
+                        "name": "location",
+                        "in": "query"
+                    },
+                    {
+                        "maxLength": 2,
+                        "type": "string",
+                        "example": "XX",
+                        "description": "TODO 認証を導入したら利用者の所属事業所で絞り込む",
+                        "name": "office-id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "maxLength": 6,
+                        "type": "string",
+                        "example": "000001",
+                        "name": "surveyor-id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "訪問結果の確認結果のリスト",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handler.VisitAnomalyResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "リクエスト形式不正",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "リクエスト数の上限超過",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "想定外のエラー",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "処理時間の上限超過",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/work-zones": {
+            "get": {
+                "description": "担当の変更は /events の zone.assigned で通知されます。",
+                "tags": [
+                    "work-zones"
+                ],
+                "summary": "作業区と担当の調査員のリストを返す",
+                "parameters": [
+                    {
+                        "maxLength": 2,
+                        "type": "string",
+                        "example": "XX",
+                        "name": "office-id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "作業区のリスト",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handler.GetWorkZonesResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "リクエスト形式不正",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "リクエスト数の上限超過",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "想定外のエラー",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "処理時間の上限超過",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/work-zones/{id}/surveyor": {
+            "put": {
+                "description": "担当が変わった場合は作業区の事業所に /events の zone.assigned で通知します。",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "work-zones"
+                ],
+                "summary": "作業区の担当の調査員を変更する",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "作業区ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "担当の調査員",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.PutWorkZoneSurveyorRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "変更後の作業区",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GetWorkZonesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエスト形式不正",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "作業区が存在しない",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "リクエスト数の上限超過",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "想定外のエラー",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "処理時間の上限超過",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "errs.ErrorCode": {
+            "type": "string",
+            "enum": [
+                "INVALID_REQUEST",
+                "UNAUTHENTICATED",
+                "FORBIDDEN",
+                "NOT_FOUND",
+                "EXCLUSION",
+                "CONFLICT",
+                "DUPLICATE",
+                "PRECONDITION_FAILED",
+                "PAYLOAD_TOO_LARGE",
+                "BUSINESS_RULE_VIOLATION",
+                "TOO_MANY_REQUESTS",
+                "CLIENT_CLOSED_REQUEST",
+                "INTERNAL",
+                "SERVICE_UNAVAILABLE",
+                "TIMEOUT"
+            ],
+            "x-enum-varnames": [
+                "InvalidRequest",
+                "Unauthenticated",
+                "Forbidden",
+                "NotFound",
+                "Exclusion",
+                "Conflict",
+                "Duplicate",
+                "PreconditionFailed",
+                "PayloadTooLarge",
+                "BusinessRuleViolation",
+                "TooManyRequests",
+                "ClientClosedRequest",
+                "Internal",
+                "ServiceUnavailable",
+                "Timeout"
+            ]
+        },
+        "handler.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/errs.ErrorCode"
+                        }
+                    ],
+                    "example": "INVALID_REQUEST"
+                },
+                "details": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "aaa",
+                        "bbb",
+                        "ccc"
+                    ]
+                },
+                "message": {
+                    "type": "string",
+                    "example": "不正なリクエストです"
+                },
+                "requestId": {
+                    "type": "string",
+                    "example": "3f8c2a6e-1d5b-4c1e-9a7f-2b6d8e0c4a13"
+                },
+                "violations": {
+                    "description": "入力項目ごとの検証エラー（入力項目を特定できるエラーの場合のみ）",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.ViolationResponse"
+                    }
+                }
+            }
+        },
+        "handler.EventResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "種類ごとの内容（ZoneAssignedEventData, CustomerMovedEventData, VisitRecordedEventData）",
+                    "type": "object"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "occurredAt": {
+                    "type": "string",
+                    "example": "2026-10-19T09:00:00+09:00"
+                },
+                "officeId": {
+                    "type": "string",
+                    "example": "XX"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "zone.assigned",
+                        "customer.moved",
+                        "visit.recorded"
+                    ],
+                    "example": "zone.assigned"
+                }
+            }
+        },
+        "handler.GetErrorCodesResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/errs.ErrorCode"
+                        }
+                    ],
+                    "example": "INVALID_REQUEST"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "リクエストの形式が不正です"
+                },
+                "messageKey": {
+                    "type": "string",
+                    "example": "error.invalidRequest"
+                },
+                "retryable": {
+                    "description": "同じリクエストを再実行すると成功する可能性があるか",
+                    "type": "boolean",
+                    "example": false
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 400
+                },
+                "type": {
+                    "description": "Problem Details形式のエラーレスポンスのtype",
+                    "type": "string",
+                    "example": "/problems/invalid-request"
+                }
+            }
+        },
+        "handler.GetOfficesResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "XX"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "〇〇事業所"
+                }
+            }
+        },
+        "handler.GetSampleResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "11111"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "サンプル1"
+                }
+            }
+        },
+        "handler.GetSurveyorsResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "000001"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "調査員1"
+                }
+            }
+        },
+        "handler.GetWorkZonesResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "Z00001"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "作業区1"
+                },
+                "officeId": {
+                    "type": "string",
+                    "example": "XX"
+                },
+                "surveyorId": {
+                    "description": "担当の調査員（未割り当ての場合は空）",
+                    "type": "string",
+                    "example": "000001"
+                }
+            }
+        },
+        "handler.LineStringGeometry": {
+            "type": "object",
+            "properties": {
+                "coordinates": {
+                    "description": "各点の経度, 緯度の配列",
+                    "type": "array",
+                    "items": {
+                        "type": "array",
+                        "items": {
+                            "type": "number",
+                            "format": "float64"
+                        }
+                    }
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "LineString"
+                    ],
+                    "example": "LineString"
+                }
+            }
+        },
+        "handler.PointGeometry": {
+            "type": "object",
+            "properties": {
+                "coordinates": {
+                    "description": "経度, 緯度の順",
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    },
+                    "example": [
+                        139.767125,
+                        35.681236
+                    ]
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "Point"
+                    ],
+                    "example": "Point"
+                }
+            }
+        },
+        "handler.PositionMessage": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "$ref": "#/definitions/handler.ErrorResponse"
+                },
+                "event": {
+                    "type": "string",
+                    "enum": [
+                        "snapshot",
+                        "position",
+                        "stale",
+                        "error"
+                    ],
+                    "example": "position"
+                },
+                "position": {
+                    "$ref": "#/definitions/handler.SurveyorPositionFeature"
+                },
+                "positions": {
+                    "$ref": "#/definitions/handler.SurveyorPositionsResponse"
+                },
+                "surveyorId": {
+                    "type": "string",
+                    "example": "000001"
+                }
+            }
+        },
+        "handler.PositionReportRequest": {
+            "type": "object",
+            "required": [
+                "recordedAt"
+            ],
+            "properties": {
+                "accuracy": {
+                    "description": "測位の誤差（メートル）",
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 12.5
+                },
+                "lat": {
+                    "type": "number",
+                    "maximum": 90,
+                    "minimum": -90,
+                    "example": 35.681236
+                },
+                "lng": {
+                    "type": "number",
+                    "maximum": 180,
+                    "minimum": -180,
+                    "example": 139.767125
+                },
+                "recordedAt": {
+                    "type": "string",
+                    "example": "2026-10-19T09:00:00+09:00"
+                }
+            }
+        },
+        "handler.PostVisitRequest": {
+            "type": "object",
+            "required": [
+                "customerId",
+                "officeId",
+                "recordedAt",
+                "status",
+                "surveyorId"
+            ],
+            "properties": {
+                "customerId": {
+                    "type": "string",
+                    "maxLength": 6,
+                    "example": "C00001"
+                },
+                "fix": {
+                    "description": "記録した時点の端末の測位結果（測位できなかった場合は省略）",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/handler.PositionReportRequest"
+                        }
+                    ]
+                },
+                "officeId": {
+                    "type": "string",
+                    "maxLength": 2,
+                    "example": "XX"
+                },
+                "recordedAt": {
+                    "description": "訪問結果を記録した日時",
+                    "type": "string",
+                    "example": "2026-10-19T14:00:00+09:00"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "VISITED",
+                        "ABSENT"
+                    ],
+                    "example": "VISITED"
+                },
+                "surveyorId": {
+                    "description": "TODO 認証を導入したら利用者の調査員ID・所属事業所を使用する",
+                    "type": "string",
+                    "maxLength": 6,
+                    "example": "000001"
+                }
+            }
+        },
+        "handler.PutCustomerLocationRequest": {
+            "type": "object",
+            "required": [
+                "workZoneId"
+            ],
+            "properties": {
+                "lat": {
+                    "type": "number",
+                    "maximum": 90,
+                    "minimum": -90,
+                    "example": 35.681236
+                },
+                "lng": {
+                    "type": "number",
+                    "maximum": 180,
+                    "minimum": -180,
+                    "example": 139.767125
+                },
+                "workZoneId": {
+                    "type": "string",
+                    "maxLength": 6,
+                    "example": "Z00002"
+                }
+            }
+        },
+        "handler.PutWorkZoneSurveyorRequest": {
+            "type": "object",
+            "required": [
+                "surveyorId"
+            ],
+            "properties": {
+                "surveyorId": {
+                    "type": "string",
+                    "maxLength": 6,
+                    "example": "000002"
+                }
+            }
+        },
+        "handler.SurveyorPositionFeature": {
+            "type": "object",
+            "properties": {
+                "geometry": {
+                    "$ref": "#/definitions/handler.PointGeometry"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "000001"
+                },
+                "properties": {
+                    "$ref": "#/definitions/handler.SurveyorPositionProperties"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "Feature"
+                    ],
+                    "example": "Feature"
+                }
+            }
+        },
+        "handler.SurveyorPositionProperties": {
+            "type": "object",
+            "properties": {
+                "accuracy": {
+                    "description": "測位の誤差（メートル）",
+                    "type": "number",
+                    "example": 12.5
+                },
+                "officeId": {
+                    "type": "string",
+                    "example": "XX"
+                },
+                "recordedAt": {
+                    "type": "string",
+                    "example": "2026-10-19T09:00:00+09:00"
+                },
+                "stale": {
+                    "description": "位置が一定時間更新されていない（端末の電源断や圏外など）",
+                    "type": "boolean",
+                    "example": false
+                },
+                "surveyorId": {
+                    "type": "string",
+                    "example": "000001"
+                }
+            }
+        },
+        "handler.SurveyorPositionsResponse": {
+            "type": "object",
+            "properties": {
+                "features": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.SurveyorPositionFeature"
+                    }
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "FeatureCollection"
+                    ],
+                    "example": "FeatureCollection"
+                }
+            }
+        },
+        "handler.SurveyorStopResponse": {
+            "type": "object",
+            "properties": {
+                "customerId": {
+                    "description": "滞在した位置の近くの担当のお客さま（該当なしの場合は省略）",
+                    "type": "string",
+                    "example": "C00001"
+                },
+                "durationSeconds": {
+                    "description": "滞在した時間（秒）",
+                    "type": "integer",
+                    "example": 900
+                },
+                "end": {
+                    "type": "string",
+                    "example": "2026-10-19T10:15:00+09:00"
+                },
+                "lat": {
+                    "type": "number",
+                    "example": 35.681236
+                },
+                "lng": {
+                    "type": "number",
+                    "example": 139.767125
+                },
+                "start": {
+                    "type": "string",
+                    "example": "2026-10-19T10:00:00+09:00"
+                }
+            }
+        },
+        "handler.SurveyorTrackProperties": {
+            "type": "object",
+            "properties": {
+                "coordTimes": {
+                    "description": "各点の測位の日時（geometryのcoordinatesと同じ順、再生に使用します）",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "2026-10-19T09:00:00+09:00",
+                        "2026-10-19T09:05:00+09:00"
+                    ]
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2026-10-19"
+                },
+                "distance": {
+                    "description": "移動距離（メートル）",
+                    "type": "number",
+                    "example": 5321.4
+                },
+                "durationSeconds": {
+                    "description": "最初の測位から最後の測位までの時間（秒）",
+                    "type": "integer",
+                    "example": 28800
+                },
+                "movingDurationSeconds": {
+                    "description": "滞在を除いた移動の時間（秒）",
+                    "type": "integer",
+                    "example": 7200
+                },
+                "rawPointCount": {
+                    "description": "簡略化する前の測位結果の件数",
+                    "type": "integer",
+                    "example": 120
+                },
+                "stops": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.SurveyorStopResponse"
+                    }
+                },
+                "surveyorId": {
+                    "type": "string",
+                    "example": "000001"
+                }
+            }
+        },
+        "handler.SurveyorTrackResponse": {
+            "type": "object",
+            "properties": {
+                "geometry": {
+                    "description": "点が2つ未満の場合は null",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/handler.LineStringGeometry"
+                        }
+                    ]
+                },
+                "id": {
+                    "type": "string",
+                    "example": "000001/2026-10-19"
+                },
+                "properties": {
+                    "$ref": "#/definitions/handler.SurveyorTrackProperties"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "Feature"
+                    ],
+                    "example": "Feature"
+                }
+            }
+        },
+        "handler.ViolationResponse": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string",
+                    "example": "office-id"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "office-idの長さは最大でも2文字でなければなりません"
+                },
+                "param": {
+                    "type": "string",
+                    "example": "2"
+                },
+                "rejectedValue": {
+                    "type": "string",
+                    "example": "ABC"
+                },
+                "rule": {
+                    "type": "string",
+                    "example": "max"
+                }
+            }
+        },
+        "handler.VisitAnomalyResponse": {
+            "type": "object",
+            "properties": {
+                "customerId": {
+                    "type": "string",
+                    "example": "C00003"
+                },
+                "fix": {
+                    "description": "記録時の端末の測位結果（測位できなかった場合は null）",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/handler.VisitFixResponse"
+                        }
+                    ]
+                },
+                "fixDistance": {
+                    "description": "記録時の測位結果とお客さまの位置の距離（メートル、測位結果がない場合は null）",
+                    "type": "number",
+                    "example": 1338.2
+                },
+                "id": {
+                    "type": "string",
+                    "example": "V00003"
+                },
+                "location": {
+                    "description": "記録した位置の分類",
+                    "type": "string",
+                    "enum": [
+                        "ON_SITE",
+                        "NEAR",
+                        "FAR",
+                        "NO_FIX"
+                    ],
+                    "example": "FAR"
+                },
+                "officeId": {
+                    "type": "string",
+                    "example": "XX"
+                },
+                "recordedAt": {
+                    "type": "string",
+                    "example": "2026-10-19T14:00:00+09:00"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "NOT_VISITED",
+                        "VISITED",
+                        "ABSENT"
+                    ],
+                    "example": "VISITED"
+                },
+                "surveyorId": {
+                    "type": "string",
+                    "example": "000002"
+                },
+                "trackDistance": {
+                    "description": "記録日時の前後の軌跡のうちお客さまの位置に最も近い点の距離（メートル、軌跡がない場合は null）",
+                    "type": "number",
+                    "example": 1290.5
+                }
+            }
+        },
+        "handler.VisitFixResponse": {
+            "type": "object",
+            "properties": {
+                "accuracy": {
+                    "description": "測位の誤差（メートル）",
+                    "type": "number",
+                    "example": 10
+                },
+                "lat": {
+                    "type": "number",
+                    "example": 35.67
+                },
+                "lng": {
+                    "type": "number",
+                    "example": 139.702
+                },
+                "recordedAt": {
+                    "type": "string",
+                    "example": "2026-10-19T14:00:00+09:00"
                 }
             }
         }
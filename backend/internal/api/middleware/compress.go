@@ -0,0 +1,282 @@
+package middleware
+
+import (
+	"bufio"
+	"compress/gzip"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"react-ts/backend/config"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/andybalholm/brotli"
+	"github.com/gin-gonic/gin"
+	"github.com/klauspost/compress/zstd"
+)
+
+// Compress はAccept-Encodingに応じてレスポンスを圧縮するミドルウェアを返します
+// config.CompressionConfig.MinSize 未満のレスポンスや、画像など圧縮の効果がない形式は圧縮しません
+// 圧縮した場合は強いETagに圧縮形式を付与します（"abc" → "abc-gzip"）
+func Compress(store *config.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		cfg := store.Current().Compression
+		if !cfg.Enabled || isUpgrade(c.Request) {
+			c.Next()
+			return
+		}
+
+		c.Writer.Header().Add("Vary", "Accept-Encoding")
+		enc := negotiateEncoding(c.GetHeader("Accept-Encoding"), cfg.Encodings)
+		if enc == "" {
+			c.Next()
+			return
+		}
+
+		// 圧縮したレスポンスのETagで条件付きリクエストされた場合は、後続の処理で比較できるよう圧縮形式を取り除く
+		inm, stripped := stripETagSuffix(c.GetHeader("If-None-Match"), enc)
+		if stripped {
+			c.Request.Header.Set("If-None-Match", inm)
+		}
+
+		w := &compressWriter{
+			ResponseWriter: c.Writer,
+			encoding:       enc,
+			minSize:        cfg.MinSize,
+			stripped:       stripped,
+			status:         c.Writer.Status(),
+		}
+		c.Writer = w
+		defer func() { c.Writer = w.ResponseWriter }()
+
+		c.Next()
+
+		w.close()
+	}
+}
+
+// negotiateEncoding はAccept-Encodingで受け入れ可能な圧縮形式のうち、優先度（q値）が最も高いものを返します
+// 優先度が同じ場合は encodings の順に選択します
+func negotiateEncoding(accept string, encodings []string) string {
+	if accept == "" {
+		return ""
+	}
+	q := map[string]float64{}
+	for part := range strings.SplitSeq(accept, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		v := 1.0
+		if p, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if f, err := strconv.ParseFloat(p, 64); err == nil {
+				v = f
+			}
+		}
+		q[strings.ToLower(strings.TrimSpace(name))] = v
+	}
+
+	best, bestQ := "", 0.0
+	for _, enc := range encodings {
+		v, ok := q[enc]
+		if !ok {
+			v, ok = q["*"]
+		}
+		if ok && v > bestQ {
+			best, bestQ = enc, v
+		}
+	}
+	return best
+}
+
+// stripETagSuffix はIf-None-Matchの各タグから圧縮形式の接尾辞を取り除きます
+func stripETagSuffix(ifNoneMatch, enc string) (string, bool) {
+	suffix := "-" + enc + `"`
+	if !strings.Contains(ifNoneMatch, suffix) {
+		return ifNoneMatch, false
+	}
+	return strings.ReplaceAll(ifNoneMatch, suffix, `"`), true
+}
+
+// addETagSuffix は強いETagに圧縮形式の接尾辞を付与します
+func addETagSuffix(h http.Header, enc string) {
+	etag := h.Get("ETag")
+	if strings.HasPrefix(etag, `"`) && strings.HasSuffix(etag, `"`) && len(etag) > 1 {
+		h.Set("ETag", etag[:len(etag)-1]+"-"+enc+`"`)
+	}
+}
+
+// compressible は圧縮の効果があるContent-Typeかを返します
+func compressible(contentType string) bool {
+	mt, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	switch {
+	case strings.HasPrefix(mt, "text/event-stream"):
+		// ストリーミングは逐次送信するため圧縮しない
+		return false
+	case strings.HasPrefix(mt, "text/"),
+		strings.HasSuffix(mt, "+json"),
+		strings.HasSuffix(mt, "+xml"):
+		return true
+	}
+	return slices.Contains([]string{"application/json", "application/javascript", "application/xml"}, mt)
+}
+
+// encoder は圧縮形式ごとのエンコーダーです
+type encoder interface {
+	io.WriteCloser
+	Flush() error
+	Reset(w io.Writer)
+}
+
+// encoderPools はエンコーダーの生成コストを抑えるためのプールです
+var encoderPools = map[string]*sync.Pool{
+	"gzip": {New: func() any {
+		e, _ := gzip.NewWriterLevel(io.Discard, gzip.DefaultCompression)
+		return e
+	}},
+	"br": {New: func() any {
+		// 動的なレスポンスのため速度を優先したレベルを使用する
+		return brotli.NewWriterLevel(io.Discard, 4)
+	}},
+	"zstd": {New: func() any {
+		e, _ := zstd.NewWriter(io.Discard, zstd.WithEncoderLevel(zstd.SpeedDefault), zstd.WithEncoderConcurrency(1))
+		return e
+	}},
+}
+
+// compressWriter はレスポンスが MinSize に達するまでバッファし、圧縮するかどうかを決定します
+type compressWriter struct {
+	gin.ResponseWriter
+	encoding string
+	minSize  int
+	stripped bool
+
+	status    int
+	headerSet bool
+	buf       []byte
+	decided   bool
+	enc       encoder
+}
+
+func (w *compressWriter) WriteHeader(code int) {
+	if w.decided {
+		w.ResponseWriter.WriteHeader(code)
+		return
+	}
+	w.status = code
+	w.headerSet = true
+}
+
+func (w *compressWriter) WriteHeaderNow() {
+	if w.decided {
+		w.ResponseWriter.WriteHeaderNow()
+	}
+}
+
+func (w *compressWriter) Write(b []byte) (int, error) {
+	if !w.decided {
+		w.buf = append(w.buf, b...)
+		if len(w.buf) >= w.minSize {
+			if err := w.decide(); err != nil {
+				return 0, err
+			}
+		}
+		return len(b), nil
+	}
+	if w.enc != nil {
+		return w.enc.Write(b)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *compressWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+func (w *compressWriter) Status() int {
+	if w.decided {
+		return w.ResponseWriter.Status()
+	}
+	return w.status
+}
+
+func (w *compressWriter) Written() bool {
+	return w.decided || len(w.buf) > 0
+}
+
+func (w *compressWriter) Flush() {
+	if !w.decided {
+		w.decide()
+	}
+	if w.enc != nil {
+		w.enc.Flush()
+	}
+	w.ResponseWriter.Flush()
+}
+
+// Unwrap は http.ResponseController が元のResponseWriterの機能（書き込みの期限など）を使用できるようにします
+func (w *compressWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func (w *compressWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	w.decided = true
+	return w.ResponseWriter.Hijack()
+}
+
+// decide は圧縮するかどうかを決定し、ヘッダーとバッファした内容を書き込みます
+func (w *compressWriter) decide() error {
+	w.decided = true
+	h := w.Header()
+
+	compress := len(w.buf) >= w.minSize &&
+		w.status >= http.StatusOK &&
+		w.status != http.StatusNoContent &&
+		w.status != http.StatusPartialContent &&
+		w.status != http.StatusNotModified &&
+		h.Get("Content-Encoding") == "" &&
+		compressible(h.Get("Content-Type"))
+
+	switch {
+	case compress:
+		h.Set("Content-Encoding", w.encoding)
+		h.Del("Content-Length")
+		addETagSuffix(h, w.encoding)
+		w.enc = encoderPools[w.encoding].Get().(encoder)
+		w.enc.Reset(w.ResponseWriter)
+	case w.status == http.StatusNotModified && w.stripped:
+		// 圧縮したレスポンスのETagで再検証された場合は同じETagを返す
+		addETagSuffix(h, w.encoding)
+	}
+
+	w.ResponseWriter.WriteHeader(w.status)
+	w.ResponseWriter.WriteHeaderNow()
+	if len(w.buf) == 0 {
+		return nil
+	}
+	var err error
+	if w.enc != nil {
+		_, err = w.enc.Write(w.buf)
+	} else {
+		_, err = w.ResponseWriter.Write(w.buf)
+	}
+	w.buf = nil
+	return err
+}
+
+// close はバッファした内容を書き込み、エンコーダーを終了します
+// 何も書き込まれていない場合はginのデフォルトのレスポンス（404など）に任せます
+func (w *compressWriter) close() {
+	if !w.decided && (w.headerSet || len(w.buf) > 0) {
+		w.decide()
+	}
+	if w.enc != nil {
+		w.enc.Close()
+		w.enc.Reset(io.Discard)
+		encoderPools[w.encoding].Put(w.enc)
+		w.enc = nil
+	}
+}
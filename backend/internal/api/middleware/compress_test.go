@@ -0,0 +1,102 @@
+package middleware
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"react-ts/backend/config"
+	"strings"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+	"github.com/gin-gonic/gin"
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Compress(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cfg := config.Config{
+		HTTPCache: config.HTTPCacheConfig{ETag: true},
+		Compression: config.CompressionConfig{
+			Enabled:   true,
+			Encodings: []string{"zstd", "br", "gzip"},
+			MinSize:   100,
+		},
+	}
+	store := config.NewStore(cfg, config.Options{}, nil)
+	large := strings.Repeat(`{"type":"Feature"},`, 100)
+
+	r := gin.New()
+	r.Use(Compress(store), HTTPCache(store))
+	r.GET("/large", func(c *gin.Context) { c.Data(http.StatusOK, "application/geo+json", []byte(large)) })
+	r.GET("/small", func(c *gin.Context) { c.String(http.StatusOK, "ok") })
+	r.GET("/image", func(c *gin.Context) { c.Data(http.StatusOK, "image/png", []byte(large)) })
+
+	decoders := map[string]func(io.Reader) io.Reader{
+		"gzip": func(r io.Reader) io.Reader { d, _ := gzip.NewReader(r); return d },
+		"br":   func(r io.Reader) io.Reader { return brotli.NewReader(r) },
+		"zstd": func(r io.Reader) io.Reader { d, _ := zstd.NewReader(r); return d },
+		"":     func(r io.Reader) io.Reader { return r },
+	}
+
+	tests := []struct {
+		name     string
+		path     string
+		accept   string
+		expected string
+	}{
+		{name: "Gzip", path: "/large", accept: "gzip", expected: "gzip"},
+		{name: "Brotli", path: "/large", accept: "gzip, br", expected: "br"},
+		{name: "Zstd", path: "/large", accept: "gzip, br, zstd", expected: "zstd"},
+		{name: "QValue", path: "/large", accept: "zstd;q=0.5, gzip", expected: "gzip"},
+		{name: "Rejected", path: "/large", accept: "gzip;q=0", expected: ""},
+		{name: "Identity", path: "/large", accept: "", expected: ""},
+		{name: "BelowMinSize", path: "/small", accept: "gzip", expected: ""},
+		{name: "NotCompressible", path: "/image", accept: "gzip", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", tt.path, nil)
+			req.Header.Set("Accept-Encoding", tt.accept)
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, tt.expected, w.Header().Get("Content-Encoding"))
+			assert.Equal(t, "Accept-Encoding", w.Header().Get("Vary"))
+			body, err := io.ReadAll(decoders[tt.expected](bytes.NewReader(w.Body.Bytes())))
+			require.NoError(t, err)
+			if tt.path == "/small" {
+				assert.Equal(t, "ok", string(body))
+			} else {
+				assert.Equal(t, large, string(body))
+			}
+			if tt.expected != "" {
+				assert.Less(t, w.Body.Len(), len(large))
+				assert.True(t, strings.HasSuffix(w.Header().Get("ETag"), "-"+tt.expected+`"`))
+			}
+		})
+	}
+
+	t.Run("NotModified", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/large", nil)
+		req.Header.Set("Accept-Encoding", "gzip")
+		r.ServeHTTP(w, req)
+		etag := w.Header().Get("ETag")
+
+		// 圧縮したレスポンスのETagで再検証できること
+		w = httptest.NewRecorder()
+		req.Header.Set("If-None-Match", etag)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotModified, w.Code)
+		assert.Equal(t, etag, w.Header().Get("ETag"))
+		assert.Empty(t, w.Header().Get("Content-Encoding"))
+	})
+}
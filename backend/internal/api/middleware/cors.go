@@ -2,31 +2,90 @@ package middleware
 
 import (
 	"react-ts/backend/config"
-	"time"
+	"slices"
+	"strings"
+	"sync/atomic"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 )
 
 // CorsHandler はCORSミドルウェアの設定を行いHandlerFuncを返します
-func CorsHandler(cfg config.Config) gin.HandlerFunc {
+// リクエストのパスに応じて config.CORSConfig.Policy のポリシーを適用し、設定の再読み込みに追従します
+//
+// preflightリクエストはルーティングされないため、ルートグループ単位ではなくEngineに設定してください
+func CorsHandler(store *config.Store) gin.HandlerFunc {
+	var current atomic.Pointer[corsHandlers]
+	build := func(cfg config.Config) {
+		current.Store(newCorsHandlers(cfg.CORS))
+	}
+	build(store.Current())
+	store.Subscribe(build)
+
+	return func(c *gin.Context) {
+		current.Load().get(c.Request.URL.Path)(c)
+	}
+}
+
+// corsHandlers はポリシーごとに生成したミドルウェアです（キーはRoutesの接頭辞、デフォルトのポリシーは空文字）
+type corsHandlers struct {
+	cfg      config.CORSConfig
+	handlers map[string]gin.HandlerFunc
+}
+
+func newCorsHandlers(cfg config.CORSConfig) *corsHandlers {
+	h := &corsHandlers{
+		cfg:      cfg,
+		handlers: map[string]gin.HandlerFunc{"": newCors(cfg.Default)},
+	}
+	for prefix := range cfg.Routes {
+		h.handlers[prefix] = newCors(cfg.Policy(prefix))
+	}
+	return h
+}
+
+// get は path に適用するミドルウェアを返します
+func (h *corsHandlers) get(path string) gin.HandlerFunc {
+	return h.handlers[h.cfg.Route(path)]
+}
+
+// newCors はポリシーからgin-contrib/corsのミドルウェアを生成します
+func newCors(p config.CORSPolicy) gin.HandlerFunc {
+	origins := p.AllowOrigins
 	return cors.New(cors.Config{
-		// アクセスを許可したいアクセス元
-		AllowOrigins: []string{
-			cfg.AllowOrigin,
+		// アクセスを許可したいアクセス元（ワイルドカードを含むため関数で判定する）
+		AllowOriginFunc: func(origin string) bool {
+			return slices.ContainsFunc(origins, func(pattern string) bool {
+				return matchOrigin(pattern, origin)
+			})
 		},
 		// 許可したいHTTPメソッド
-		AllowMethods: []string{
-			"GET",
-			"POST",
-			"OPTIONS",
-		},
+		AllowMethods: p.AllowMethods,
 		// 許可したいHTTPヘッダー
-		AllowHeaders: []string{
-			"Origin",
-			"Content-Type",
-		},
+		AllowHeaders: p.AllowHeaders,
+		// ブラウザから参照を許可したいレスポンスヘッダー
+		ExposeHeaders: p.ExposeHeaders,
+		// Cookieや認証情報を含むリクエストの許可
+		AllowCredentials: p.AllowCredentials,
 		// preflightリクエストの結果をキャッシュする時間
-		MaxAge: 24 * time.Hour,
+		MaxAge: p.MaxAge,
 	})
 }
+
+// matchOrigin はオリジンがパターンに一致するかを返します
+// "https://*.example.com" は "https://a.example.com" や "https://a.b.example.com" に一致し、"https://example.com" には一致しません
+func matchOrigin(pattern, origin string) bool {
+	if pattern == "*" || pattern == origin {
+		return true
+	}
+	before, after, ok := strings.Cut(pattern, "*")
+	if !ok || len(origin) <= len(before)+len(after) {
+		return false
+	}
+	if !strings.HasPrefix(origin, before) || !strings.HasSuffix(origin, after) {
+		return false
+	}
+	// ワイルドカード部分はサブドメインのみ（ポートやパスを含めない）
+	sub := origin[len(before) : len(origin)-len(after)]
+	return !strings.ContainsAny(sub, ":/@")
+}
@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"react-ts/backend/config"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CorsHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cfg := config.Config{
+		CORS: config.CORSConfig{
+			Default: config.CORSPolicy{
+				AllowOrigins:  []string{"http://localhost:5173", "https://*.preview.example.com"},
+				AllowMethods:  []string{"GET", "PUT", "DELETE"},
+				AllowHeaders:  []string{"Authorization", "If-Match", "X-Request-ID"},
+				ExposeHeaders: []string{"X-Request-ID"},
+				MaxAge:        time.Hour,
+			},
+			Routes: map[string]config.CORSPolicy{
+				// 管理用のエンドポイントは特定のオリジンのみ許可する
+				"/v1/admin": {AllowOrigins: []string{"https://admin.example.com"}, AllowCredentials: true},
+			},
+		},
+	}
+	r := gin.New()
+	r.Use(CorsHandler(config.NewStore(cfg, config.Options{}, nil)))
+	r.GET("/v1/surveyors", func(c *gin.Context) { c.Status(http.StatusOK) })
+	r.GET("/v1/admin/users", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	tests := []struct {
+		name    string
+		path    string
+		origin  string
+		allowed bool
+	}{
+		{name: "Exact", path: "/v1/surveyors", origin: "http://localhost:5173", allowed: true},
+		{name: "Wildcard", path: "/v1/surveyors", origin: "https://pr-12.preview.example.com", allowed: true},
+		{name: "WildcardApex", path: "/v1/surveyors", origin: "https://preview.example.com", allowed: false},
+		{name: "WildcardPort", path: "/v1/surveyors", origin: "https://a.preview.example.com:8443", allowed: false},
+		{name: "Unknown", path: "/v1/surveyors", origin: "https://evil.example.net", allowed: false},
+		{name: "AdminAllowed", path: "/v1/admin/users", origin: "https://admin.example.com", allowed: true},
+		{name: "AdminRejected", path: "/v1/admin/users", origin: "http://localhost:5173", allowed: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// preflightリクエスト
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("OPTIONS", tt.path, nil)
+			req.Header.Set("Origin", tt.origin)
+			req.Header.Set("Access-Control-Request-Method", "PUT")
+			req.Header.Set("Access-Control-Request-Headers", "Authorization,If-Match")
+			r.ServeHTTP(w, req)
+
+			assert := assert.New(t)
+			if tt.allowed {
+				assert.Equal(http.StatusNoContent, w.Code)
+				assert.Equal(tt.origin, w.Header().Get("Access-Control-Allow-Origin"))
+				assert.Contains(w.Header().Get("Access-Control-Allow-Methods"), "PUT")
+				assert.Contains(w.Header().Get("Access-Control-Allow-Headers"), "Authorization")
+			} else {
+				assert.Equal(http.StatusForbidden, w.Code)
+				assert.Empty(w.Header().Get("Access-Control-Allow-Origin"))
+			}
+		})
+	}
+}
@@ -0,0 +1,20 @@
+package middleware
+
+import (
+	"net/http"
+	"react-ts/backend/config"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Feature は機能フラグ name が無効の場合に404を返すミドルウェアを返します
+// フラグは設定の再読み込みに追従するため、再起動せずに機能の公開・停止を切り替えられます
+func Feature(store *config.Store, name string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !store.Current().FeatureEnabled(name) {
+			c.AbortWithStatus(http.StatusNotFound)
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"react-ts/backend/config"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Feature(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	file := filepath.Join(t.TempDir(), "config.yaml")
+	write := func(content string) {
+		if err := os.WriteFile(file, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("features:\n  swagger: false\n")
+	args := []string{"-config", file}
+	cfg, opts, err := config.Load(args)
+	if err != nil {
+		t.Fatal(err)
+	}
+	store := config.NewStore(cfg, opts, args)
+
+	r := gin.New()
+	r.GET("/swagger/*any", Feature(store, config.FeatureSwagger), func(c *gin.Context) {
+		c.String(http.StatusOK, "swagger")
+	})
+	get := func() int {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/swagger/index.html", nil)
+		r.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	assert.Equal(t, http.StatusNotFound, get())
+
+	// 再読み込みで有効にした機能は再起動せずに公開される
+	write("features:\n  swagger: true\n")
+	if _, err := store.Reload(); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, http.StatusOK, get())
+}
@@ -0,0 +1,158 @@
+package middleware
+
+import (
+	"bufio"
+	"bytes"
+	"net"
+	"net/http"
+	"react-ts/backend/config"
+	"react-ts/backend/internal/etag"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HTTPCache はGET/HEADのレスポンスにCache-ControlとETagを付与し、If-None-Matchが一致する場合は304を返すミドルウェアを返します
+// ETagはハンドラーがデータのバージョンなどから設定した値を優先し、設定されていない場合はレスポンスの内容から生成します
+// 設定は config.HTTPCacheConfig に従い、再読み込みに追従します
+func HTTPCache(store *config.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead || isUpgrade(c.Request) {
+			c.Next()
+			return
+		}
+
+		cfg := store.Current().HTTPCache
+		w := &cacheWriter{ResponseWriter: c.Writer, status: c.Writer.Status()}
+		c.Writer = w
+		defer func() { c.Writer = w.ResponseWriter }()
+
+		c.Next()
+
+		// 何も書き込まれていない場合はginのデフォルトのレスポンス（404など）に任せる
+		if w.passthrough || (!w.headerSet && w.body.Len() == 0) {
+			return
+		}
+		h := w.Header()
+		// 成功したレスポンスのみキャッシュの対象とする
+		// ハンドラーがデータのバージョンから304を返した場合も、200と同じCache-Controlを付与する
+		if w.status == http.StatusOK || w.status == http.StatusNotModified {
+			if cc := cfg.CacheControlFor(c.Request.URL.Path); cc != "" && h.Get("Cache-Control") == "" {
+				h.Set("Cache-Control", cc)
+			}
+		}
+		if w.status == http.StatusOK {
+			if cfg.ETag {
+				if h.Get("ETag") == "" {
+					h.Set("ETag", etag.Strong(w.body.Bytes()))
+				}
+				if etag.Match(c.GetHeader("If-None-Match"), h.Get("ETag")) {
+					h.Del("Content-Type")
+					h.Del("Content-Length")
+					w.ResponseWriter.WriteHeader(http.StatusNotModified)
+					w.ResponseWriter.WriteHeaderNow()
+					return
+				}
+			}
+		}
+		w.flushBuffer()
+	}
+}
+
+// isUpgrade はプロトコルの切り替え（WebSocketのハンドシェイクなど）を要求するリクエストかを返します
+// 切り替えのレスポンス（101）はバッファや圧縮をせずにそのまま書き込む必要があります
+func isUpgrade(r *http.Request) bool {
+	for v := range strings.SplitSeq(r.Header.Get("Connection"), ",") {
+		if strings.EqualFold(strings.TrimSpace(v), "upgrade") {
+			return true
+		}
+	}
+	return false
+}
+
+// cacheWriter はETagを生成するためにレスポンスをバッファします
+// Flush（ストリーミング）やHijack（WebSocket）された場合はバッファせずにそのまま書き込みます
+type cacheWriter struct {
+	gin.ResponseWriter
+	status      int
+	headerSet   bool
+	body        bytes.Buffer
+	passthrough bool
+}
+
+func (w *cacheWriter) WriteHeader(code int) {
+	if w.passthrough {
+		w.ResponseWriter.WriteHeader(code)
+		return
+	}
+	w.status = code
+	w.headerSet = true
+}
+
+func (w *cacheWriter) WriteHeaderNow() {
+	if w.passthrough {
+		w.ResponseWriter.WriteHeaderNow()
+	}
+}
+
+func (w *cacheWriter) Write(b []byte) (int, error) {
+	if w.passthrough {
+		return w.ResponseWriter.Write(b)
+	}
+	return w.body.Write(b)
+}
+
+func (w *cacheWriter) WriteString(s string) (int, error) {
+	if w.passthrough {
+		return w.ResponseWriter.WriteString(s)
+	}
+	return w.body.WriteString(s)
+}
+
+func (w *cacheWriter) Status() int {
+	if w.passthrough {
+		return w.ResponseWriter.Status()
+	}
+	return w.status
+}
+
+func (w *cacheWriter) Size() int {
+	if w.passthrough {
+		return w.ResponseWriter.Size()
+	}
+	if w.body.Len() == 0 {
+		return -1
+	}
+	return w.body.Len()
+}
+
+func (w *cacheWriter) Written() bool {
+	return w.passthrough || w.body.Len() > 0
+}
+
+func (w *cacheWriter) Flush() {
+	if !w.passthrough {
+		w.flushBuffer()
+	}
+	w.ResponseWriter.Flush()
+}
+
+// Unwrap は http.ResponseController が元のResponseWriterの機能（書き込みの期限など）を使用できるようにします
+func (w *cacheWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func (w *cacheWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	w.passthrough = true
+	return w.ResponseWriter.Hijack()
+}
+
+// flushBuffer はバッファしたレスポンスを書き込み、以降はそのまま書き込むようにします
+func (w *cacheWriter) flushBuffer() {
+	w.passthrough = true
+	w.ResponseWriter.WriteHeader(w.status)
+	w.ResponseWriter.WriteHeaderNow()
+	if w.body.Len() > 0 {
+		w.ResponseWriter.Write(w.body.Bytes())
+	}
+}
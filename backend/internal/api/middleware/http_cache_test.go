@@ -0,0 +1,114 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"react-ts/backend/config"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_HTTPCache(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cfg := config.Config{
+		HTTPCache: config.HTTPCacheConfig{
+			ETag:         true,
+			CacheControl: "no-cache",
+			Routes: map[string]string{
+				"/v1/surveyors":          "private, max-age=60",
+				"/v1/surveyors/*/tracks": "no-cache",
+			},
+		},
+	}
+	r := gin.New()
+	r.Use(HTTPCache(config.NewStore(cfg, config.Options{}, nil)))
+	r.GET("/v1/surveyors", func(c *gin.Context) { c.JSON(http.StatusOK, []string{"a", "b"}) })
+	r.GET("/v1/samples", func(c *gin.Context) {
+		// データのバージョンから設定したETagを優先する
+		c.Header("ETag", `"v42"`)
+		c.JSON(http.StatusOK, []string{"c"})
+	})
+	r.GET("/v1/surveyors/:id/tracks", func(c *gin.Context) { c.JSON(http.StatusOK, []string{"t"}) })
+	r.GET("/v1/surveyors/:id", func(c *gin.Context) {
+		// ハンドラーがデータのバージョンから304を返す
+		c.Header("ETag", `"v1"`)
+		c.Status(http.StatusNotModified)
+	})
+	r.GET("/v1/errors", func(c *gin.Context) { c.JSON(http.StatusBadRequest, gin.H{}) })
+
+	get := func(path, inm string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", path, nil)
+		if inm != "" {
+			req.Header.Set("If-None-Match", inm)
+		}
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	w := get("/v1/surveyors", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `["a","b"]`, w.Body.String())
+	assert.Equal(t, "private, max-age=60", w.Header().Get("Cache-Control"))
+	etag := w.Header().Get("ETag")
+	assert.Regexp(t, `^"[A-Za-z0-9_-]+"$`, etag)
+
+	// ETagが一致する場合は304を返す
+	w = get("/v1/surveyors", `"other", `+etag)
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.Empty(t, w.Body.String())
+	assert.Equal(t, etag, w.Header().Get("ETag"))
+
+	w = get("/v1/samples", `W/"v42"`)
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.Equal(t, "no-cache", w.Header().Get("Cache-Control"))
+
+	// パスパラメーターを含むルートは * で指定した値を適用する
+	w = get("/v1/surveyors/123/tracks", "")
+	assert.Equal(t, "no-cache", w.Header().Get("Cache-Control"))
+
+	// ハンドラーが返した304にも200と同じCache-Controlを付与する
+	w = get("/v1/surveyors/123", `"v1"`)
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.Equal(t, `"v1"`, w.Header().Get("ETag"))
+	assert.Equal(t, "private, max-age=60", w.Header().Get("Cache-Control"))
+
+	// エラーレスポンスはキャッシュの対象外
+	w = get("/v1/errors", "")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Empty(t, w.Header().Get("ETag"))
+	assert.Empty(t, w.Header().Get("Cache-Control"))
+
+	// 存在しないルートはginのデフォルトのレスポンスを返す
+	w = get("/unknown", "")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, "404 page not found", w.Body.String())
+}
+
+// プロトコルの切り替え（WebSocketのハンドシェイク）はバッファせずにそのまま書き込むこと
+func Test_HTTPCache_Upgrade(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cfg := config.Config{HTTPCache: config.HTTPCacheConfig{ETag: true, CacheControl: "no-cache"}}
+	w := httptest.NewRecorder()
+	r := gin.New()
+	r.Use(HTTPCache(config.NewStore(cfg, config.Options{}, nil)))
+	r.GET("/ws", func(c *gin.Context) {
+		c.Writer.WriteHeader(http.StatusSwitchingProtocols)
+		c.Writer.WriteHeaderNow()
+		// 接続を切り替える前にレスポンスが送信されていること
+		assert.True(t, w.Flushed || w.Code == http.StatusSwitchingProtocols)
+	})
+
+	req, _ := http.NewRequest("GET", "/ws", nil)
+	req.Header.Set("Connection", "keep-alive, Upgrade")
+	req.Header.Set("Upgrade", "websocket")
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusSwitchingProtocols, w.Code)
+	assert.Empty(t, w.Header().Get("ETag"))
+	assert.Empty(t, w.Header().Get("Cache-Control"))
+}
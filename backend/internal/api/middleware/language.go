@@ -0,0 +1,23 @@
+package middleware
+
+import (
+	"react-ts/backend/config"
+	"react-ts/backend/internal/i18n"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Language はAccept-Languageヘッダーからレスポンスの言語を選択してcontextに設定するミドルウェアを返します
+// 対応している言語が指定されていない場合は config.I18nConfig.DefaultLanguage を使用します
+func Language(store *config.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		def := i18n.Lang(store.Current().I18n.DefaultLanguage)
+		lang := i18n.Match(c.GetHeader("Accept-Language"), def)
+
+		c.Request = c.Request.WithContext(i18n.NewContext(c.Request.Context(), lang))
+		c.Header("Content-Language", string(lang))
+		c.Writer.Header().Add("Vary", "Accept-Language")
+
+		c.Next()
+	}
+}
@@ -0,0 +1,84 @@
+package middleware
+
+import (
+	"log/slog"
+	"net/url"
+	"react-ts/backend/config"
+	"react-ts/backend/internal/logging"
+	"react-ts/backend/internal/requestid"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// AccessLogger はリクエスト単位のロガーをcontextに設定し、レスポンス後にアクセスログを出力するミドルウェアを返します
+// リクエストIDをログに含めるため、RequestIDミドルウェアの後に設定してください
+func AccessLogger(cfg config.Config) gin.HandlerFunc {
+	redact := make(map[string]struct{}, len(cfg.Log.RedactParams))
+	for _, p := range cfg.Log.RedactParams {
+		redact[strings.ToLower(p)] = struct{}{}
+	}
+
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		// 後続の処理（ユースケース・リポジトリ）から利用できるようにcontextへロガーを設定する
+		// RequestIDミドルウェアで設定されたリクエストIDは全てのログに付与する
+		l := slog.Default()
+		if id := requestid.FromContext(c.Request.Context()); id != "" {
+			l = l.With(slog.String("requestId", id))
+		}
+		// トレースと突き合わせできるようにトレースIDも付与する
+		if sc := trace.SpanContextFromContext(c.Request.Context()); sc.IsValid() {
+			l = l.With(slog.String("traceId", sc.TraceID().String()))
+		}
+		ctx := logging.NewContext(c.Request.Context(), l)
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+
+		status := c.Writer.Status()
+		level := slog.LevelInfo
+		switch {
+		case status >= 500:
+			level = slog.LevelError
+		case status >= 400:
+			level = slog.LevelWarn
+		}
+
+		// ハンドラー内で属性が追加されている可能性があるため、contextから取り直す
+		logging.FromContext(c.Request.Context()).LogAttrs(c.Request.Context(), level, "access",
+			slog.String("method", c.Request.Method),
+			slog.String("route", c.FullPath()),
+			slog.String("path", c.Request.URL.Path),
+			slog.String("query", redactQuery(c.Request.URL.RawQuery, redact)),
+			slog.Int("status", status),
+			slog.Duration("latency", time.Since(start)),
+			slog.Int("bytes", max(c.Writer.Size(), 0)),
+			slog.String("clientIp", c.ClientIP()),
+			slog.String("user", c.GetString(gin.AuthUserKey)),
+		)
+	}
+}
+
+// redactQuery はクエリ文字列のうち、マスク対象のパラメータ値を置き換えた文字列を返します
+func redactQuery(raw string, redact map[string]struct{}) string {
+	if raw == "" {
+		return ""
+	}
+	q, err := url.ParseQuery(raw)
+	if err != nil {
+		// 解析できないクエリは値を含む可能性があるため出力しない
+		return "[UNPARSABLE]"
+	}
+	for k, vs := range q {
+		if _, ok := redact[strings.ToLower(k)]; ok {
+			for i := range vs {
+				vs[i] = "REDACTED"
+			}
+		}
+	}
+	return q.Encode()
+}
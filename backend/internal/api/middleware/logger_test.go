@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"react-ts/backend/config"
+	"react-ts/backend/internal/logging"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_AccessLogger(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// ログの出力先をバッファに差し替える
+	var buf bytes.Buffer
+	defer slog.SetDefault(slog.Default())
+	slog.SetDefault(slog.New(slog.NewJSONHandler(&buf, nil)))
+
+	r := gin.New()
+	r.Use(AccessLogger(config.Config{Log: config.LogConfig{RedactParams: []string{"token"}}}))
+	r.GET("/items/:id", func(c *gin.Context) {
+		// contextにロガーが設定されていること
+		assert.NotNil(t, logging.FromContext(c.Request.Context()))
+		c.String(http.StatusTeapot, "hello")
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/items/1?token=secret&q=abc", nil)
+	r.ServeHTTP(w, req)
+
+	var rec map[string]any
+	if assert.NoError(t, json.Unmarshal(buf.Bytes(), &rec)) {
+		assert := assert.New(t)
+		assert.Equal("WARN", rec["level"])
+		assert.Equal("GET", rec["method"])
+		assert.Equal("/items/:id", rec["route"])
+		assert.Equal("q=abc&token=REDACTED", rec["query"])
+		assert.EqualValues(http.StatusTeapot, rec["status"])
+		assert.EqualValues(5, rec["bytes"])
+		assert.NotContains(buf.String(), "secret")
+	}
+}
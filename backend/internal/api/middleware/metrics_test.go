@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"react-ts/backend/internal/metrics"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Metrics(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	r.Use(Metrics())
+	r.GET("/items/:id", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	counter := metrics.HTTPRequestsTotal.WithLabelValues("GET", "/items/:id", "200")
+	unmatched := metrics.HTTPRequestsTotal.WithLabelValues("GET", "unmatched", "404")
+	before, beforeUnmatched := testutil.ToFloat64(counter), testutil.ToFloat64(unmatched)
+
+	for _, path := range []string{"/items/1", "/items/2", "/unknown"} {
+		req, _ := http.NewRequest("GET", path, nil)
+		r.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	assert := assert.New(t)
+	// パスではなくルートテンプレート単位で集計されること
+	assert.Equal(before+2, testutil.ToFloat64(counter))
+	assert.Equal(beforeUnmatched+1, testutil.ToFloat64(unmatched))
+}
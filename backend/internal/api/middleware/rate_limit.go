@@ -0,0 +1,90 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"react-ts/backend/config"
+	"react-ts/backend/internal/errs"
+	"react-ts/backend/internal/logging"
+	"react-ts/backend/internal/ratelimit"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// APIKeyHeader はAPIキーを指定するリクエストヘッダーです
+const APIKeyHeader = "X-API-Key"
+
+// RateLimit はクライアントごとにリクエスト数を制限するミドルウェアを返します
+// 制限を超えた場合は errs.TooManyRequests のエラーを設定するため、handler.ErrorHandler の後に設定してください
+// ポリシーは config.RateLimitConfig.Policy に従い、設定の再読み込みに追従します
+// X-RateLimit-Limit は期間あたりのリクエスト数、X-RateLimit-Policy は「リクエスト数;w=期間の秒数;burst=連続して許可する数」です
+// X-RateLimit-Remaining・X-RateLimit-Reset はトークンバケットの残りと、満杯に戻るまでの秒数です
+func RateLimit(store *config.Store, limiter ratelimit.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		cfg := store.Current()
+		if !cfg.RateLimit.Enabled {
+			c.Next()
+			return
+		}
+
+		ctx := c.Request.Context()
+		path := c.Request.URL.Path
+		p := cfg.RateLimit.Policy(path)
+		limit := ratelimit.Limit{
+			Rate:  float64(p.Requests) / p.Period.Seconds(),
+			Burst: p.Burst,
+		}
+		if limit.Burst == 0 {
+			limit.Burst = p.Requests
+		}
+
+		// ルートのポリシーごとに別々に計数する
+		key := cfg.RateLimit.Route(path) + "|" + clientKey(c, cfg.Auth.APIKeys)
+		res, err := limiter.Take(ctx, key, limit)
+		if err != nil {
+			// 制限の判定ができない場合はリクエストを許可する
+			logging.FromContext(ctx).WarnContext(ctx, "rate limiter unavailable", "error", err)
+			c.Next()
+			return
+		}
+
+		h := c.Writer.Header()
+		h.Set("X-RateLimit-Limit", strconv.Itoa(p.Requests))
+		h.Set("X-RateLimit-Policy", fmt.Sprintf("%d;w=%d;burst=%d", p.Requests, ceilSeconds(p.Period), limit.Burst))
+		h.Set("X-RateLimit-Remaining", strconv.Itoa(res.Remaining))
+		h.Set("X-RateLimit-Reset", strconv.Itoa(ceilSeconds(res.Reset)))
+		if !res.Allowed {
+			h.Set("Retry-After", strconv.Itoa(ceilSeconds(res.RetryAfter)))
+			c.Error(errs.NewBusinessError(errs.TooManyRequests)).SetType(gin.ErrorTypePublic)
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// clientKey はレート制限の単位となるクライアントの識別子を返します
+// 認証済みのユーザー、登録済みのAPIキー、クライアントIPの順で識別します
+// クライアントIPは config.ServerConfig.TrustedProxies のプロキシからの接続の場合のみ X-Forwarded-For を使用します
+func clientKey(c *gin.Context, apiKeys []string) string {
+	if user := c.GetString(gin.AuthUserKey); user != "" {
+		return "user:" + user
+	}
+	// 未登録のAPIキーで制限を回避できないよう、登録済みのキーのみ識別に使用する
+	if key := c.GetHeader(APIKeyHeader); key != "" && slices.Contains(apiKeys, key) {
+		// キーそのものは保持しない
+		sum := sha256.Sum256([]byte(key))
+		return "apikey:" + hex.EncodeToString(sum[:8])
+	}
+	return "ip:" + c.ClientIP()
+}
+
+// ceilSeconds は時間を秒単位に切り上げます
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
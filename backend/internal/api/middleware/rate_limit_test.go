@@ -0,0 +1,127 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"react-ts/backend/config"
+	"react-ts/backend/internal/api/v1/handler"
+	"react-ts/backend/internal/errs"
+	"react-ts/backend/internal/ratelimit"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_RateLimit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cfg := config.Config{
+		Auth: config.AuthConfig{APIKeys: []string{"tool-key"}},
+		RateLimit: config.RateLimitConfig{
+			Enabled: true,
+			Default: config.RateLimitPolicy{Requests: 60, Period: time.Minute, Burst: 3},
+			Routes: map[string]config.RateLimitPolicy{
+				"/v1/surveyors": {Requests: 1, Period: time.Hour},
+			},
+		},
+	}
+	store := config.NewStore(cfg, config.Options{}, nil)
+	r := gin.New()
+	r.Use(handler.ErrorHandler(store))
+	r.Use(RateLimit(store, ratelimit.NewMemoryStore()))
+	r.GET("/v1/surveyors", func(c *gin.Context) { c.Status(http.StatusOK) })
+	r.GET("/v1/samples", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	get := func(path, apiKey string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", path, nil)
+		if apiKey != "" {
+			req.Header.Set(APIKeyHeader, apiKey)
+		}
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	// ルートのポリシーでは1件のみ許可される
+	w := get("/v1/surveyors", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "1", w.Header().Get("X-RateLimit-Limit"))
+	assert.Equal(t, "1;w=3600;burst=1", w.Header().Get("X-RateLimit-Policy"))
+	assert.Equal(t, "0", w.Header().Get("X-RateLimit-Remaining"))
+	assert.Equal(t, "3600", w.Header().Get("X-RateLimit-Reset"))
+
+	w = get("/v1/surveyors", "")
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "3600", w.Header().Get("Retry-After"))
+	var res handler.ErrorResponse
+	if assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &res)) {
+		assert.Equal(t, errs.TooManyRequests, res.Code)
+	}
+
+	// 登録済みのAPIキーはクライアントIPとは別に計数される
+	assert.Equal(t, http.StatusOK, get("/v1/surveyors", "tool-key").Code)
+	// 未登録のAPIキーはクライアントIPとして計数される
+	assert.Equal(t, http.StatusTooManyRequests, get("/v1/surveyors", "unknown").Code)
+
+	// 他のルートはデフォルトのポリシーで別に計数される
+	for i := range 3 {
+		w = get("/v1/samples", "")
+		assert.Equal(t, http.StatusOK, w.Code)
+		// 上限は連続して許可する数ではなく期間あたりのリクエスト数
+		assert.Equal(t, "60", w.Header().Get("X-RateLimit-Limit"))
+		assert.Equal(t, "60;w=60;burst=3", w.Header().Get("X-RateLimit-Policy"))
+		assert.Equal(t, "", w.Header().Get("Retry-After"), i)
+	}
+	assert.Equal(t, http.StatusTooManyRequests, get("/v1/samples", "").Code)
+}
+
+// 信頼するプロキシ以外からの X-Forwarded-For ではクライアントを識別しないこと
+func Test_RateLimit_TrustedProxies(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cfg := config.Config{
+		RateLimit: config.RateLimitConfig{
+			Enabled: true,
+			Default: config.RateLimitPolicy{Requests: 1, Period: time.Hour},
+		},
+	}
+	store := config.NewStore(cfg, config.Options{}, nil)
+
+	tests := []struct {
+		name    string
+		proxies []string
+		// 2件目のリクエストが X-Forwarded-For を変えた別のクライアントとして扱われるか
+		allowed bool
+	}{
+		{name: "None", proxies: nil, allowed: false},
+		{name: "Trusted", proxies: []string{"10.0.0.0/8"}, allowed: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := gin.New()
+			assert.NoError(t, r.SetTrustedProxies(tt.proxies))
+			r.Use(handler.ErrorHandler(store))
+			r.Use(RateLimit(store, ratelimit.NewMemoryStore()))
+			r.GET("/v1/samples", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+			get := func(xff string) int {
+				w := httptest.NewRecorder()
+				req, _ := http.NewRequest("GET", "/v1/samples", nil)
+				req.RemoteAddr = "10.0.0.1:12345"
+				req.Header.Set("X-Forwarded-For", xff)
+				r.ServeHTTP(w, req)
+				return w.Code
+			}
+
+			assert.Equal(t, http.StatusOK, get("203.0.113.1"))
+			if tt.allowed {
+				assert.Equal(t, http.StatusOK, get("203.0.113.2"))
+			} else {
+				assert.Equal(t, http.StatusTooManyRequests, get("203.0.113.2"))
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"react-ts/backend/internal/requestid"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// リクエストIDとして受け付ける最大長
+const maxRequestIDLength = 128
+
+// RequestID はX-Request-IDヘッダーを受け付け（無い場合は生成し）、contextとレスポンスヘッダーに設定するミドルウェアを返します
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(requestid.Header)
+		if !isValidRequestID(id) {
+			id = uuid.NewString()
+		}
+
+		c.Request = c.Request.WithContext(requestid.NewContext(c.Request.Context(), id))
+		c.Header(requestid.Header, id)
+
+		c.Next()
+	}
+}
+
+// isValidRequestID はクライアントから受け取ったリクエストIDをそのまま使用してよいか判定します
+// ログやヘッダーへの混入を防ぐため、表示可能なASCII文字のみ許可します
+func isValidRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x21 || id[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,96 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"react-ts/backend/config"
+	"react-ts/backend/internal/logging"
+	"react-ts/backend/internal/requestid"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_RequestID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name     string
+		header   string
+		accepted bool
+	}{
+		{name: "Generated", header: "", accepted: false},
+		{name: "Accepted", header: "req-0001", accepted: true},
+		{name: "MaxLength", header: strings.Repeat("a", maxRequestIDLength), accepted: true},
+		{name: "TooLong", header: strings.Repeat("a", maxRequestIDLength+1), accepted: false},
+		{name: "Space", header: "req 0001", accepted: false},
+		{name: "NonASCII", header: "リクエスト", accepted: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			r := gin.New()
+			r.Use(RequestID())
+			r.GET("/dummy", func(c *gin.Context) {
+				got = requestid.FromContext(c.Request.Context())
+			})
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/dummy", nil)
+			if tt.header != "" {
+				req.Header.Set(requestid.Header, tt.header)
+			}
+			r.ServeHTTP(w, req)
+
+			assert := assert.New(t)
+			// contextとレスポンスヘッダーに同じIDが設定されること
+			assert.NotEmpty(got)
+			assert.Equal(got, w.Header().Get(requestid.Header))
+			if tt.accepted {
+				assert.Equal(tt.header, got)
+			} else {
+				// 受け付けられないIDは生成したIDに置き換える
+				assert.NotEqual(tt.header, got)
+				assert.NoError(uuid.Validate(got))
+			}
+		})
+	}
+}
+
+func Test_RequestID_Logger(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var buf bytes.Buffer
+	defer slog.SetDefault(slog.Default())
+	slog.SetDefault(slog.New(slog.NewJSONHandler(&buf, nil)))
+
+	r := gin.New()
+	r.Use(RequestID(), AccessLogger(config.Config{}))
+	r.GET("/dummy", func(c *gin.Context) {
+		ctx := c.Request.Context()
+		logging.FromContext(ctx).InfoContext(ctx, "handled")
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/dummy", nil)
+	req.Header.Set(requestid.Header, "req-0001")
+	r.ServeHTTP(w, req)
+
+	// ハンドラーのログとアクセスログの両方にリクエストIDが付与されること
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if assert.Len(t, lines, 2) {
+		for _, line := range lines {
+			var rec map[string]any
+			if assert.NoError(t, json.Unmarshal([]byte(line), &rec)) {
+				assert.Equal(t, "req-0001", rec["requestId"])
+			}
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"context"
+	"react-ts/backend/config"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequestTimeout はリクエストのcontextに処理時間の上限を設定するミドルウェアを返します
+// 上限は config.ServerConfig.RequestTimeoutFor に従い、設定の再読み込みに追従します
+// 上限を超えると後続の処理（ユースケース・リポジトリ）に渡したcontextがキャンセルされ、
+// 返された context.DeadlineExceeded は handler.ErrorHandler で errs.Timeout のエラーレスポンスになります
+func RequestTimeout(store *config.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		d := store.Current().Server.RequestTimeoutFor(c.Request.URL.Path)
+		if d <= 0 {
+			c.Next()
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), d)
+		defer cancel()
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+	}
+}
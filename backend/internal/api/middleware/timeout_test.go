@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"react-ts/backend/config"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_RequestTimeout(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cfg := config.Config{
+		Server: config.ServerConfig{
+			RequestTimeout: time.Second,
+			RouteTimeouts: map[string]time.Duration{
+				"/v1/reports": time.Minute,
+				"/v1/events":  0,
+			},
+		},
+	}
+	r := gin.New()
+	r.Use(RequestTimeout(config.NewStore(cfg, config.Options{}, nil)))
+
+	deadlines := map[string]time.Duration{}
+	handler := func(c *gin.Context) {
+		if d, ok := c.Request.Context().Deadline(); ok {
+			deadlines[c.FullPath()] = time.Until(d)
+		}
+		c.Status(http.StatusOK)
+	}
+	r.GET("/v1/surveyors", handler)
+	r.GET("/v1/reports", handler)
+	r.GET("/v1/events", handler)
+
+	for _, path := range []string{"/v1/surveyors", "/v1/reports", "/v1/events"} {
+		req, _ := http.NewRequest("GET", path, nil)
+		r.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	assert.InDelta(t, time.Second, deadlines["/v1/surveyors"], float64(100*time.Millisecond))
+	assert.InDelta(t, time.Minute, deadlines["/v1/reports"], float64(100*time.Millisecond))
+	// 0の場合は上限を設けないこと
+	assert.NotContains(t, deadlines, "/v1/events")
+}
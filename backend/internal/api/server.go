@@ -1,31 +1,186 @@
 package api
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
 	"react-ts/backend/config"
 	_ "react-ts/backend/docs"
 	"react-ts/backend/internal/api/middleware"
+	"react-ts/backend/internal/api/system"
 	v1 "react-ts/backend/internal/api/v1"
+	"react-ts/backend/internal/api/v1/handler"
 	"react-ts/backend/internal/bootstrap"
+	"react-ts/backend/internal/metrics"
+	"react-ts/backend/internal/tlsutil"
+	"react-ts/backend/internal/tracing"
 
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/quic-go/quic-go/http3"
 	swaggerfiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 )
 
 // Run はサーバーを設定し起動します
-func Run(cfg config.Config, cp *bootstrap.Components) {
-	// ginのDefault Routerを生成
-	r := gin.Default()
+// ctx がキャンセルされると新規の接続の受付を停止し、処理中のリクエストの完了を cfg.Server.ShutdownTimeout まで待ってから戻ります
+func Run(ctx context.Context, store *config.Store, cp *bootstrap.Components) error {
+	cfg := store.Current()
+
+	// ginのRouterを生成（ログはslogで出力するためgin標準のLoggerは使用しない）
+	r := gin.New()
+	// クライアントIP（レート制限・アクセスログ）は信頼するプロキシからの X-Forwarded-For のみ使用する
+	if err := r.SetTrustedProxies(cfg.Server.TrustedProxies); err != nil {
+		return fmt.Errorf("failed to set trusted proxies: %w", err)
+	}
 
 	// ミドルウェアの設定
-	r.Use(middleware.CorsHandler(cfg))
+	r.Use(otelgin.Middleware(tracing.ServiceName))
+	r.Use(middleware.RequestID())
+	r.Use(middleware.Language(store))
+	r.Use(middleware.AccessLogger(cfg))
+	r.Use(middleware.Metrics())
+	r.Use(handler.Recovery(store))
+	r.Use(middleware.RequestTimeout(store))
+	r.Use(middleware.CorsHandler(store))
+	r.Use(middleware.Compress(store))
+	r.Use(middleware.HTTPCache(store))
 
 	// 各エンドポイントのルーティング
-	v1.Route(r, cp)
+	v1.Route(r, store, cp)
+	system.Route(r, cp)
+
+	// Swagger UIのルーティング（機能フラグで公開を切り替える）
+	r.GET("/swagger/*any", middleware.Feature(store, config.FeatureSwagger), ginSwagger.WrapHandler(swaggerfiles.Handler))
+
+	// 起動するサーバー
+	main := newServer(cfg, ":"+cfg.Server.Port, r)
+	// 停止時は処理中のリクエストの完了を待つため、終わらないストリーミング（変更通知・位置の共有）を先に終了させる
+	main.RegisterOnShutdown(cp.Events.Close)
+	main.RegisterOnShutdown(cp.Positions.Close)
+	servers := []server{httpServer(main)}
+
+	// TLSの設定（HTTP/2はTLS接続時のみ有効）
+	if cfg.TLS.Enabled() {
+		tc, reloader, err := tlsutil.NewConfig(cfg.TLS)
+		if err != nil {
+			return fmt.Errorf("failed to configure TLS: %w", err)
+		}
+		if reloader != nil {
+			go reloader.Watch(ctx, cfg.TLS.ReloadInterval)
+		}
+		main.TLSConfig = tc
+		main.Protocols = new(http.Protocols)
+		main.Protocols.SetHTTP1(true)
+		main.Protocols.SetHTTP2(cfg.TLS.HTTP2)
+		servers[0] = httpsServer(main)
+
+		// HTTP/3は同じポート番号のUDPで待ち受け、TCP側のレスポンスのAlt-Svcヘッダーで通知する
+		if cfg.TLS.HTTP3 {
+			h3 := &http3.Server{
+				Addr:           main.Addr,
+				Handler:        r,
+				TLSConfig:      http3.ConfigureTLSConfig(tc),
+				IdleTimeout:    cfg.Server.IdleTimeout,
+				MaxHeaderBytes: cfg.Server.MaxHeaderBytes,
+			}
+			main.Handler = altSvc(h3, r)
+			servers = append(servers, server{
+				name:     "http3",
+				addr:     h3.Addr,
+				serve:    h3.ListenAndServe,
+				shutdown: h3.Shutdown,
+			})
+		}
+	}
 
-	// Swagger UIのルーティング
-	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerfiles.Handler))
+	// メトリクスのルーティング（Goランタイムのメトリクスはデフォルトのレジストリに登録済み）
+	if err := prometheus.Register(metrics.NewBusinessCollector(cp.StatisticsUC)); err != nil {
+		return fmt.Errorf("failed to register business metrics: %w", err)
+	}
+	if cfg.Metrics.Port == "" {
+		r.GET("/metrics", gin.WrapH(promhttp.Handler()))
+	} else {
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", promhttp.Handler())
+		servers = append(servers, httpServer(newServer(cfg, ":"+cfg.Metrics.Port, mux)))
+	}
 
 	// サーバー起動
-	r.Run(":" + cfg.Port)
+	errCh := make(chan error, len(servers))
+	for _, srv := range servers {
+		go func() {
+			slog.Info("server started", "protocol", srv.name, "addr", srv.addr)
+			if err := srv.serve(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				errCh <- fmt.Errorf("%s server %s stopped: %w", srv.name, srv.addr, err)
+			}
+		}()
+	}
+
+	// 停止要求またはサーバーのエラーを待つ
+	var runErr error
+	select {
+	case <-ctx.Done():
+		slog.Info("shutting down server")
+	case runErr = <-errCh:
+	}
+
+	// 処理中のリクエストの完了を待って停止する
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+	defer cancel()
+	for _, srv := range servers {
+		if err := srv.shutdown(shutdownCtx); err != nil {
+			runErr = errors.Join(runErr, fmt.Errorf("failed to shut down %s server %s: %w", srv.name, srv.addr, err))
+		}
+	}
+	return runErr
+}
+
+// newServer はタイムアウト等を設定したhttp.Serverを生成します
+func newServer(cfg config.Config, addr string, h http.Handler) *http.Server {
+	return &http.Server{
+		Addr:              addr,
+		Handler:           h,
+		ReadTimeout:       cfg.Server.ReadTimeout,
+		ReadHeaderTimeout: cfg.Server.ReadHeaderTimeout,
+		WriteTimeout:      cfg.Server.WriteTimeout,
+		IdleTimeout:       cfg.Server.IdleTimeout,
+		MaxHeaderBytes:    cfg.Server.MaxHeaderBytes,
+	}
+}
+
+// server は起動・停止するサーバーです（http.Server と http3.Server を同じように扱うためのもの）
+type server struct {
+	name     string
+	addr     string
+	serve    func() error
+	shutdown func(ctx context.Context) error
+}
+
+// httpServer は平文で待ち受けるサーバーを返します
+func httpServer(srv *http.Server) server {
+	return server{name: "http", addr: srv.Addr, serve: srv.ListenAndServe, shutdown: srv.Shutdown}
+}
+
+// httpsServer は srv.TLSConfig の証明書でTLSの待ち受けを行うサーバーを返します
+func httpsServer(srv *http.Server) server {
+	return server{
+		name:     "https",
+		addr:     srv.Addr,
+		serve:    func() error { return srv.ListenAndServeTLS("", "") },
+		shutdown: srv.Shutdown,
+	}
+}
+
+// altSvc はHTTP/3で接続できることを通知するAlt-Svcヘッダーをレスポンスに付与します
+func altSvc(h3 *http3.Server, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		// HTTP/3の待ち受け開始前はポートが確定しないためエラーになるが、その場合は通知しない
+		_ = h3.SetQUICHeaders(w.Header())
+		next.ServeHTTP(w, req)
+	})
 }
@@ -0,0 +1,34 @@
+package system
+
+import (
+	"net/http"
+	"react-ts/backend/internal/health"
+
+	"github.com/gin-gonic/gin"
+)
+
+type GetHealthzResponse struct {
+	Status health.Status `json:"status" example:"UP"`
+}
+
+// GetHealthz はプロセスが稼働していれば常に200を返します（liveness probe用）
+func GetHealthz() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, GetHealthzResponse{Status: health.StatusUp})
+	}
+}
+
+// GetReadyz は登録された依存先のチェック結果を返します（readiness probe用）
+// チェックは bootstrap.NewComponents で登録します（データベース、変更通知・位置の共有のブローカー）
+// いずれかのチェックが失敗した場合は503を返します
+func GetReadyz(reg *health.Registry) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		rep := reg.Check(c.Request.Context())
+
+		status := http.StatusOK
+		if rep.Status != health.StatusUp {
+			status = http.StatusServiceUnavailable
+		}
+		c.JSON(status, rep)
+	}
+}
@@ -0,0 +1,83 @@
+package system
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"react-ts/backend/internal/health"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GetReadyz(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	ok := func(ctx context.Context) error { return nil }
+	ng := func(ctx context.Context) error { return errors.New("connection refused") }
+	// contextを無視して応答しないチェック
+	hang := func(ctx context.Context) error { time.Sleep(time.Second); return nil }
+
+	tests := []struct {
+		name           string
+		checks         map[string]health.CheckFunc
+		expectedStatus int
+		expectedChecks map[string]health.Status
+	}{
+		{
+			name:           "NoChecks",
+			checks:         map[string]health.CheckFunc{},
+			expectedStatus: http.StatusOK,
+			expectedChecks: map[string]health.Status{},
+		},
+		{
+			name:           "AllUp",
+			checks:         map[string]health.CheckFunc{"database": ok, "blob": ok},
+			expectedStatus: http.StatusOK,
+			expectedChecks: map[string]health.Status{"database": health.StatusUp, "blob": health.StatusUp},
+		},
+		{
+			name:           "OneDown",
+			checks:         map[string]health.CheckFunc{"database": ok, "blob": ng},
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedChecks: map[string]health.Status{"database": health.StatusUp, "blob": health.StatusDown},
+		},
+		{
+			name:           "Timeout",
+			checks:         map[string]health.CheckFunc{"database": hang},
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedChecks: map[string]health.Status{"database": health.StatusDown},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reg := health.NewRegistry(50 * time.Millisecond)
+			for name, fn := range tt.checks {
+				reg.Register(name, 0, fn)
+			}
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest("GET", "/readyz", nil)
+
+			GetReadyz(reg)(c)
+
+			assert := assert.New(t)
+			assert.Equal(tt.expectedStatus, w.Code)
+
+			var rep health.Report
+			if assert.NoError(json.Unmarshal(w.Body.Bytes(), &rep)) {
+				actual := map[string]health.Status{}
+				for name, r := range rep.Checks {
+					actual[name] = r.Status
+				}
+				assert.Equal(tt.expectedChecks, actual)
+			}
+		})
+	}
+}
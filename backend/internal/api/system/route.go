@@ -0,0 +1,15 @@
+package system
+
+import (
+	"react-ts/backend/internal/bootstrap"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Route はロードバランサーやコンテナオーケストレーターから参照されるエンドポイントを設定します
+// バージョン管理の対象外のため /v1 配下には置きません
+func Route(r *gin.Engine, cp *bootstrap.Components) {
+	r.GET("/healthz", GetHealthz())
+	r.GET("/readyz", GetReadyz(cp.Health))
+	r.GET("/version", GetVersion())
+}
@@ -0,0 +1,15 @@
+package system
+
+import (
+	"net/http"
+	"react-ts/backend/internal/buildinfo"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetVersion はビルド情報（コミット、ビルド日時、Goのバージョン）を返します
+func GetVersion() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, buildinfo.Get())
+	}
+}
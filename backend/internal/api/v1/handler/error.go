@@ -1,67 +1,174 @@
 package handler
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"log"
+	"react-ts/backend/config"
 	"react-ts/backend/internal/errs"
+	"react-ts/backend/internal/i18n"
+	"react-ts/backend/internal/logging"
+	"react-ts/backend/internal/metrics"
+	"react-ts/backend/internal/requestid"
+	"react-ts/backend/internal/tracing"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // ErrorResponse エラーレスポンスの構造体
 // Swaggerのドキュメント生成用に各ハンドラーから参照されることを想定しています
 type ErrorResponse struct {
-	Code    string   `json:"code" example:"INVALID_REQUEST"`
-	Message string   `json:"message" example:"不正なリクエストです"`
-	Details []string `json:"details" example:"aaa,bbb,ccc"`
+	Code    errs.ErrorCode `json:"code" example:"INVALID_REQUEST"`
+	Message string         `json:"message" example:"不正なリクエストです"`
+	Details []string       `json:"details" example:"aaa,bbb,ccc"`
+	// 入力項目ごとの検証エラー（入力項目を特定できるエラーの場合のみ）
+	Violations []ViolationResponse `json:"violations,omitempty"`
+	RequestID  string              `json:"requestId" example:"3f8c2a6e-1d5b-4c1e-9a7f-2b6d8e0c4a13"`
+}
+
+// ViolationResponse 入力項目ごとの検証エラーの構造体
+type ViolationResponse struct {
+	Field         string `json:"field" example:"office-id"`
+	Rule          string `json:"rule" example:"max"`
+	Param         string `json:"param" example:"2"`
+	RejectedValue any    `json:"rejectedValue" swaggertype:"string" example:"ABC"`
+	Message       string `json:"message" example:"office-idの長さは最大でも2文字でなければなりません"`
 }
 
 // ErrorHandler はgin.ErrorTypePublicであるエラーが検出された場合クライアントにJSONエラーレスポンスを送信して処理を中断するmiddleware。
 // 各ハンドラーはエラー処理で「c.Error(err).SetType(gin.ErrorTypePublic)」を呼ぶ必要がある
-func ErrorHandler() gin.HandlerFunc {
+// レスポンスの形式は config.ErrorsConfig とAcceptヘッダーに従い、ErrorResponse または ProblemDetails とする
+func ErrorHandler(store *config.Store) gin.HandlerFunc {
 	return func(c *gin.Context) {
 
 		c.Next()
 
 		err := c.Errors.ByType(gin.ErrorTypePublic).Last()
 		if err != nil {
-			cd := errs.Internal
-			details := []string{}
+			ctx := c.Request.Context()
 			var e *errs.BusinessError
-			if errors.As(err, &e) {
-				cd = e.GetCode()
-				details = e.GetDetails()
-			} else {
+			var se *errs.SystemError
+			switch {
+			case errors.As(err, &e):
+			case errors.Is(err, context.DeadlineExceeded), errors.Is(err, context.Canceled):
+				// タイムアウトやクライアントの切断は想定内のため警告とする
+				logging.FromContext(ctx).WarnContext(ctx, "request aborted", "error", err.Err)
+			case errors.As(err, &se):
+				// システムエラーは発生箇所を特定できるようにスタックトレースを出力する
+				logging.FromContext(ctx).ErrorContext(ctx, "system error", "error", err.Err, "stack", se.Stack())
+			default:
 				// 業務エラーでない場合はログを出力する
-				log.Printf("System Error: %v\n", err.Err)
+				logging.FromContext(ctx).ErrorContext(ctx, "system error", "error", err.Err)
 			}
+			abortWithError(c, store, err.Err)
+		}
 
-			res := ErrorResponse{
-				Code:    string(cd),
-				Message: cd.GetMessage(),
-				Details: details,
-			}
-			c.AbortWithStatusJSON(cd.GetStatus(), res)
+	}
+}
+
+// abortWithError はエラーをエラーレスポンスに変換して送信し、処理を中断します。
+func abortWithError(c *gin.Context, store *config.Store, err error) {
+	ctx := c.Request.Context()
+	res := newErrorResponse(ctx, err)
+	cd := res.Code
+
+	metrics.ErrorResponsesTotal.WithLabelValues(string(cd)).Inc()
+	tracing.RecordError(trace.SpanFromContext(ctx), err)
+
+	cfg := store.Current().Errors
+	if wantsProblem(c.GetHeader("Accept"), cfg.Format == "problem") {
+		c.Header("Content-Type", problemContentType)
+		c.AbortWithStatusJSON(cd.GetStatus(), newProblemDetails(res, cd.GetStatus(), c.Request.URL.Path, cfg.ProblemTypeBaseURI))
+		return
+	}
+	c.AbortWithStatusJSON(cd.GetStatus(), res)
+}
+
+// newErrorResponse はエラーをエラーレスポンスに変換します。
+// contextのタイムアウトは errs.Timeout、キャンセル（クライアントの切断）は errs.ClientClosedRequest、
+// システムエラーはそのエラーコード、それ以外のエラーは errs.Internal として扱います。
+func newErrorResponse(ctx context.Context, err error) ErrorResponse {
+	cd := errs.Internal
+	details := []string{}
+	var violations []ViolationResponse
+	var e *errs.BusinessError
+	var se *errs.SystemError
+	switch {
+	case errors.As(err, &e):
+		cd = e.GetCode()
+		details = e.GetDetails()
+		for _, v := range e.GetViolations() {
+			violations = append(violations, ViolationResponse(v))
 		}
+	case errors.Is(err, context.DeadlineExceeded):
+		cd = errs.Timeout
+	case errors.Is(err, context.Canceled):
+		cd = errs.ClientClosedRequest
+	case errors.As(err, &se):
+		cd = se.GetCode()
+	}
+
+	return ErrorResponse{
+		Code:       cd,
+		Message:    cd.Message(i18n.FromContext(ctx)),
+		Details:    details,
+		Violations: violations,
+		RequestID:  requestid.FromContext(ctx),
+	}
+}
+
+// newInvalidRequestError はリクエストのバインド・バリデーションのエラーから業務エラーを生成します。
+// バリデーションエラーの場合は入力項目ごとの検証エラーを設定し、details にはそのメッセージを設定します。
+// メッセージはcontextに設定された言語で生成します。
+func newInvalidRequestError(ctx context.Context, err error) *errs.BusinessError {
+	violations := createViolations(ctx, err)
+	if violations == nil {
+		// バリデーションエラーではない場合（数値や日付の形式が不正など）は、そのままエラーメッセージを返す
+		return errs.NewBusinessError(errs.InvalidRequest, err.Error())
+	}
 
+	details := make([]string, len(violations))
+	for i, v := range violations {
+		details[i] = v.Message
 	}
+	return errs.NewBusinessError(errs.InvalidRequest, details...).WithViolations(violations...)
 }
 
-// createValidationDetails はバリデーションエラーから詳細なメッセージのスライスを生成します。
-func createValidationDetails(err error) []string {
+// createViolations はバリデーションエラーから入力項目ごとの検証エラーを生成します。
+// バリデーションエラーではない場合は nil を返します。
+func createViolations(ctx context.Context, err error) []errs.Violation {
 	var ve validator.ValidationErrors
-	// エラーがバリデーションエラーではない場合は、そのままエラーメッセージを返す
 	if !errors.As(err, &ve) {
-		return []string{err.Error()}
+		return nil
 	}
 
-	details := make([]string, len(ve))
+	trans, ok := translators[i18n.FromContext(ctx)]
+	violations := make([]errs.Violation, len(ve))
 	for i, fe := range ve {
-		// TODO ここでタグに応じて日本語のメッセージを返すことも可能です
-		details[i] = fmt.Sprintf("Field validation for '%s' failed on the '%s' tag", fe.Field(), fe.Tag())
+		msg := fmt.Sprintf("Field validation for '%s' failed on the '%s' tag", fe.Field(), fe.Tag())
+		if ok {
+			msg = fe.Translate(trans)
+		}
+		violations[i] = errs.Violation{
+			Field:         fieldPath(fe),
+			Rule:          fe.Tag(),
+			Param:         fe.Param(),
+			RejectedValue: fe.Value(),
+			Message:       msg,
+		}
 	}
 
-	return details
+	return violations
+}
+
+// fieldPath はリクエストの構造体名を除いた項目のパス（items[0].name など）を返します。
+func fieldPath(fe validator.FieldError) string {
+	_, path, ok := strings.Cut(fe.Namespace(), ".")
+	if !ok {
+		return fe.Field()
+	}
+	return path
 }
@@ -1,11 +1,16 @@
 package handler
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"react-ts/backend/config"
 	"react-ts/backend/internal/errs"
+	"react-ts/backend/internal/i18n"
+	"react-ts/backend/internal/requestid"
 	"testing"
 
 	"github.com/gin-gonic/gin"
@@ -18,6 +23,8 @@ func Test_ErrorHandler(t *testing.T) {
 	tests := []struct {
 		name             string
 		err              error
+		requestID        string
+		lang             i18n.Lang
 		expectedStatus   int
 		expectedResponse ErrorResponse
 	}{
@@ -26,7 +33,7 @@ func Test_ErrorHandler(t *testing.T) {
 			err:            errs.NewBusinessError(errs.Exclusion, "xxxx", "yyyy"),
 			expectedStatus: errs.Exclusion.GetStatus(),
 			expectedResponse: ErrorResponse{
-				Code:    string(errs.Exclusion),
+				Code:    errs.Exclusion,
 				Message: errs.Exclusion.GetMessage(),
 				Details: []string{"xxxx", "yyyy"},
 			},
@@ -36,21 +43,84 @@ func Test_ErrorHandler(t *testing.T) {
 			err:            errs.NewSystemError("zzzzz", errors.New("error")),
 			expectedStatus: 500,
 			expectedResponse: ErrorResponse{
-				Code:    string(errs.Internal),
+				Code:    errs.Internal,
 				Message: errs.Internal.GetMessage(),
 				Details: []string{},
 			},
 		},
+		{
+			name:           "SystemError with code",
+			err:            fmt.Errorf("wrapped: %w", errs.NewSystemError("zzzzz", errors.New("error")).WithCode(errs.ServiceUnavailable)),
+			expectedStatus: 503,
+			expectedResponse: ErrorResponse{
+				Code:    errs.ServiceUnavailable,
+				Message: errs.ServiceUnavailable.GetMessage(),
+				Details: []string{},
+			},
+		},
 		{
 			name:           "error",
 			err:            errors.New("error"),
 			expectedStatus: 500,
 			expectedResponse: ErrorResponse{
-				Code:    string(errs.Internal),
+				Code:    errs.Internal,
 				Message: errs.Internal.GetMessage(),
 				Details: []string{},
 			},
 		},
+		{
+			name:           "RequestID",
+			err:            errs.NewBusinessError(errs.NotFound),
+			requestID:      "req-0001",
+			expectedStatus: errs.NotFound.GetStatus(),
+			expectedResponse: ErrorResponse{
+				Code:      errs.NotFound,
+				Message:   errs.NotFound.GetMessage(),
+				RequestID: "req-0001",
+			},
+		},
+		{
+			name: "Violations",
+			err: errs.NewBusinessError(errs.InvalidRequest, "office-idの長さは最大でも2文字でなければなりません").
+				WithViolations(errs.Violation{Field: "office-id", Rule: "max", Param: "2", RejectedValue: "ABC", Message: "office-idの長さは最大でも2文字でなければなりません"}),
+			expectedStatus: errs.InvalidRequest.GetStatus(),
+			expectedResponse: ErrorResponse{
+				Code:       errs.InvalidRequest,
+				Message:    errs.InvalidRequest.GetMessage(),
+				Details:    []string{"office-idの長さは最大でも2文字でなければなりません"},
+				Violations: []ViolationResponse{{Field: "office-id", Rule: "max", Param: "2", RejectedValue: "ABC", Message: "office-idの長さは最大でも2文字でなければなりません"}},
+			},
+		},
+		{
+			name:           "DeadlineExceeded",
+			err:            fmt.Errorf("failed to get surveyors: %w", context.DeadlineExceeded),
+			expectedStatus: 504,
+			expectedResponse: ErrorResponse{
+				Code:    errs.Timeout,
+				Message: errs.Timeout.GetMessage(),
+				Details: []string{},
+			},
+		},
+		{
+			name:           "Canceled",
+			err:            errs.NewSystemError("canceled", context.Canceled),
+			expectedStatus: 499,
+			expectedResponse: ErrorResponse{
+				Code:    errs.ClientClosedRequest,
+				Message: errs.ClientClosedRequest.GetMessage(),
+				Details: []string{},
+			},
+		},
+		{
+			name:           "English",
+			err:            errs.NewBusinessError(errs.NotFound),
+			lang:           i18n.English,
+			expectedStatus: errs.NotFound.GetStatus(),
+			expectedResponse: ErrorResponse{
+				Code:    errs.NotFound,
+				Message: "The requested data was not found",
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -58,7 +128,7 @@ func Test_ErrorHandler(t *testing.T) {
 
 			w := httptest.NewRecorder()
 			r := gin.New()
-			r.Use(ErrorHandler())
+			r.Use(ErrorHandler(newTestStore("json")))
 
 			// テスト用のハンドラーを定義
 			r.GET("/dummy", func(c *gin.Context) {
@@ -67,6 +137,12 @@ func Test_ErrorHandler(t *testing.T) {
 
 			// リクエストを作成して実行
 			req, _ := http.NewRequest("GET", "/dummy", nil)
+			if tt.requestID != "" {
+				req = req.WithContext(requestid.NewContext(req.Context(), tt.requestID))
+			}
+			if tt.lang != "" {
+				req = req.WithContext(i18n.NewContext(req.Context(), tt.lang))
+			}
 			r.ServeHTTP(w, req)
 
 			assert := assert.New(t)
@@ -77,3 +153,63 @@ func Test_ErrorHandler(t *testing.T) {
 		})
 	}
 }
+
+func Test_ErrorHandler_ProblemDetails(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	violation := errs.Violation{Field: "office-id", Rule: "max", Param: "2", RejectedValue: "ABC", Message: "office-idの長さは最大でも2文字でなければなりません"}
+	tests := []struct {
+		name    string
+		format  string
+		accept  string
+		problem bool
+	}{
+		{name: "DefaultJSON", format: "json", accept: "*/*", problem: false},
+		{name: "AcceptProblem", format: "json", accept: "application/problem+json, application/json;q=0.9", problem: true},
+		{name: "ConfigProblem", format: "problem", accept: "", problem: true},
+		{name: "AcceptJSON", format: "problem", accept: "application/json", problem: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := gin.New()
+			r.Use(ErrorHandler(newTestStore(tt.format)))
+			r.GET("/v1/surveyors", func(c *gin.Context) {
+				err := errs.NewBusinessError(errs.InvalidRequest, violation.Message).WithViolations(violation)
+				c.Error(err).SetType(gin.ErrorTypePublic)
+			})
+
+			req, _ := http.NewRequest("GET", "/v1/surveyors", nil)
+			req.Header.Set("Accept", tt.accept)
+			req = req.WithContext(requestid.NewContext(req.Context(), "req-0001"))
+			r.ServeHTTP(w, req)
+
+			assert := assert.New(t)
+			assert.Equal(http.StatusBadRequest, w.Code)
+			if !tt.problem {
+				assert.Equal("application/json; charset=utf-8", w.Header().Get("Content-Type"))
+				return
+			}
+
+			assert.Equal("application/problem+json", w.Header().Get("Content-Type"))
+			expected, _ := json.Marshal(ProblemDetails{
+				Type:       "/problems/invalid-request",
+				Title:      errs.InvalidRequest.GetMessage(),
+				Status:     http.StatusBadRequest,
+				Detail:     violation.Message,
+				Instance:   "/v1/surveyors",
+				Code:       errs.InvalidRequest,
+				RequestID:  "req-0001",
+				Violations: []ViolationResponse{ViolationResponse(violation)},
+			})
+			assert.JSONEq(string(expected), w.Body.String())
+		})
+	}
+}
+
+// newTestStore はエラーレスポンスの形式を指定した設定を返します
+func newTestStore(format string) *config.Store {
+	cfg := config.Config{Errors: config.ErrorsConfig{Format: format, ProblemTypeBaseURI: "/problems/"}}
+	return config.NewStore(cfg, config.Options{}, nil)
+}
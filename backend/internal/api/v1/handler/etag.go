@@ -0,0 +1,21 @@
+package handler
+
+import (
+	"net/http"
+	"react-ts/backend/internal/etag"
+
+	"github.com/gin-gonic/gin"
+)
+
+// notModified はデータのバージョンから生成したETagをレスポンスに設定し、If-None-Matchが一致する場合は304を返します
+// データを取得する前に呼び出すことで、変更がない場合はデータの取得とレスポンスの生成を省略できます
+// resource はバージョンが同じでも内容が異なるデータ（調査員とお客さまなど）のETagが一致しないよう付与する名前です
+func notModified(c *gin.Context, resource, version string) bool {
+	tag := etag.Strong([]byte(resource + "/" + version))
+	c.Header("ETag", tag)
+	if !etag.Match(c.GetHeader("If-None-Match"), tag) {
+		return false
+	}
+	c.Status(http.StatusNotModified)
+	return true
+}
@@ -0,0 +1,22 @@
+package handler
+
+// GeoJSON（RFC 7946）のレスポンスのContent-Type
+const geoJSONContentType = "application/geo+json"
+
+// PointGeometry GeoJSONの点の構造体
+type PointGeometry struct {
+	Type string `json:"type" enums:"Point" example:"Point"`
+	// 経度, 緯度の順
+	Coordinates []float64 `json:"coordinates" example:"139.767125,35.681236"`
+}
+
+func newPointGeometry(lat, lng float64) PointGeometry {
+	return PointGeometry{Type: "Point", Coordinates: []float64{lng, lat}}
+}
+
+// LineStringGeometry GeoJSONの線の構造体
+type LineStringGeometry struct {
+	Type string `json:"type" enums:"LineString" example:"LineString"`
+	// 各点の経度, 緯度の配列
+	Coordinates [][]float64 `json:"coordinates"`
+}
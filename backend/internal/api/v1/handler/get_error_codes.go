@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"react-ts/backend/config"
+	"react-ts/backend/internal/errs"
+	"react-ts/backend/internal/i18n"
+
+	"github.com/gin-gonic/gin"
+)
+
+type GetErrorCodesResponse struct {
+	Code       errs.ErrorCode `json:"code" example:"INVALID_REQUEST"`
+	Status     int            `json:"status" example:"400"`
+	MessageKey string         `json:"messageKey" example:"error.invalidRequest"`
+	Message    string         `json:"message" example:"リクエストの形式が不正です"`
+	// 同じリクエストを再実行すると成功する可能性があるか
+	Retryable bool `json:"retryable" example:"false"`
+	// Problem Details形式のエラーレスポンスのtype
+	Type string `json:"type" example:"/problems/invalid-request"`
+}
+
+// GetErrorCodes godoc
+//
+//	@Summary		エラーコードの一覧を返す
+//	@Description	エラーレスポンスのcodeの一覧です。メッセージはAccept-Languageの言語で返します
+//	@Tags			error-codes
+//	@Success		200	{array}		GetErrorCodesResponse	"エラーコードの一覧"
+//	@Failure		429	{object}	ErrorResponse	"リクエスト数の上限超過"
+//	@Router			/error-codes [get]
+func GetErrorCodes(store *config.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		lang := i18n.FromContext(c.Request.Context())
+		base := store.Current().Errors.ProblemTypeBaseURI
+
+		codes := errs.Codes()
+		res := make([]GetErrorCodesResponse, 0, len(codes))
+		for _, cd := range codes {
+			res = append(res, GetErrorCodesResponse{
+				Code:       cd.Code,
+				Status:     cd.Status,
+				MessageKey: cd.MessageKey,
+				Message:    cd.Code.Message(lang),
+				Retryable:  cd.Retryable,
+				Type:       problemType(base, string(cd.Code)),
+			})
+		}
+		c.JSON(200, res)
+	}
+}
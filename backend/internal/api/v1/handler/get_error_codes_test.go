@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"react-ts/backend/docs"
+	"react-ts/backend/internal/errs"
+	"react-ts/backend/internal/i18n"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetErrorCodes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/dummy", nil)
+	c.Request = c.Request.WithContext(i18n.NewContext(c.Request.Context(), i18n.English))
+
+	GetErrorCodes(newTestStore("json"))(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var res []GetErrorCodesResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	assert.Len(t, res, len(errs.Codes()))
+	assert.Contains(t, res, GetErrorCodesResponse{
+		Code:       errs.TooManyRequests,
+		Status:     http.StatusTooManyRequests,
+		MessageKey: "error.tooManyRequests",
+		Message:    "Too many requests. Please wait a moment and try again",
+		Retryable:  true,
+		Type:       "/problems/too-many-requests",
+	})
+}
+
+// Swaggerのエラーコードの定義がエラーコードの一覧と一致すること（swag init の実行漏れの検出）
+func Test_SwaggerErrorCodes(t *testing.T) {
+	var doc struct {
+		Definitions map[string]struct {
+			Enum []errs.ErrorCode `json:"enum"`
+		} `json:"definitions"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(docs.SwaggerInfo.ReadDoc()), &doc))
+
+	var codes []errs.ErrorCode
+	for _, c := range errs.Codes() {
+		codes = append(codes, c.Code)
+	}
+	assert.ElementsMatch(t, codes, doc.Definitions["errs.ErrorCode"].Enum)
+}
@@ -0,0 +1,183 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"react-ts/backend/config"
+	"react-ts/backend/internal/domain"
+	"react-ts/backend/internal/errs"
+	"react-ts/backend/internal/event"
+	"react-ts/backend/internal/logging"
+	"strconv"
+	"time"
+
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+)
+
+// 切断された場合にクライアント（EventSource）が再接続するまでの時間（ミリ秒）
+const eventRetryMillis = 3000
+
+// 再送すべき通知が破棄されているため、クライアントにデータの再取得を求める通知の種類
+const eventTypeReset = "reset"
+
+type GetEventsRequest struct {
+	// TODO 認証を導入したら利用者の所属事業所で絞り込む
+	OfficeID string `form:"office-id" binding:"required,alphanum,max=2" example:"XX"`
+}
+
+type EventResponse struct {
+	ID         uint64    `json:"id" example:"42"`
+	Type       string    `json:"type" enums:"zone.assigned,customer.moved,visit.recorded" example:"zone.assigned"`
+	OfficeID   string    `json:"officeId" example:"XX"`
+	OccurredAt time.Time `json:"occurredAt" example:"2026-10-19T09:00:00+09:00"`
+	// 種類ごとの内容（ZoneAssignedEventData, CustomerMovedEventData, VisitRecordedEventData）
+	Data any `json:"data" swaggertype:"object"`
+}
+
+type ZoneAssignedEventData struct {
+	WorkZoneID         string `json:"workZoneId" example:"Z0001"`
+	SurveyorID         string `json:"surveyorId" example:"000001"`
+	PreviousSurveyorID string `json:"previousSurveyorId" example:"000002"`
+}
+
+type CustomerMovedEventData struct {
+	CustomerID string  `json:"customerId" example:"C000001"`
+	WorkZoneID string  `json:"workZoneId" example:"Z0001"`
+	Lat        float64 `json:"lat" example:"35.681236"`
+	Lng        float64 `json:"lng" example:"139.767125"`
+}
+
+type VisitRecordedEventData struct {
+	CustomerID string `json:"customerId" example:"C000001"`
+	SurveyorID string `json:"surveyorId" example:"000001"`
+	Status     string `json:"status" enums:"NOT_VISITED,VISITED,ABSENT" example:"VISITED"`
+}
+
+// GetEvents godoc
+//
+//	@Summary		作業区の担当・お客さまの位置・訪問結果の変更をServer-Sent Eventsで通知する
+//	@Description	各通知の event は通知の種類、id は通番、data は EventResponse のJSONです。
+//	@Description	通知は PUT /work-zones/{id}/surveyor（zone.assigned）、PUT /customers/{id}/location（customer.moved）、POST /visits（visit.recorded）の更新後に送信します。
+//	@Description	再接続時に Last-Event-ID ヘッダーを指定すると、それ以降の通知を再送します。
+//	@Description	再送すべき通知が既に破棄されている場合は event が reset の通知を送信するため、データを取得し直してください。
+//	@Description	通知がない間は接続を維持するためにコメント行を送信します。
+//	@Tags			events
+//	@Produce		text/event-stream
+//	@Param			q				query		GetEventsRequest	true	"検索条件"
+//	@Param			Last-Event-ID	header		string				false	"最後に受信した通知のid"
+//	@Success		200				{object}	EventResponse		"変更通知のストリーム"
+//	@Failure		400				{object}	ErrorResponse		"リクエスト形式不正"
+//	@Failure		429				{object}	ErrorResponse		"リクエスト数の上限超過"
+//	@Failure		500				{object}	ErrorResponse		"想定外のエラー"
+//	@Failure		503				{object}	ErrorResponse		"サーバーの停止中"
+//	@Router			/events [get]
+func GetEvents(broker *event.Broker, store *config.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+
+		var p GetEventsRequest
+		if err := c.ShouldBind(&p); err != nil {
+			err := newInvalidRequestError(c.Request.Context(), err)
+			c.Error(err).SetType(gin.ErrorTypePublic)
+			return
+		}
+
+		// 不正なLast-Event-IDは再送できないため、破棄済みとして扱う
+		lastID, err := strconv.ParseUint(c.GetHeader("Last-Event-ID"), 10, 64)
+		invalidLastID := err != nil && c.GetHeader("Last-Event-ID") != ""
+
+		sub, err := broker.Subscribe(lastID, func(e domain.Event) bool {
+			return e.OfficeID == p.OfficeID
+		})
+		if err != nil {
+			if errors.Is(err, event.ErrClosed) {
+				err = errs.NewSystemError("GetEvents", err).WithCode(errs.ServiceUnavailable)
+			}
+			c.Error(err).SetType(gin.ErrorTypePublic)
+			return
+		}
+		defer sub.Close()
+
+		ctx := c.Request.Context()
+		// ストリーミング中はサーバーの書き込みの期限（WriteTimeout）を適用しない
+		if err := http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{}); err != nil {
+			logging.FromContext(ctx).DebugContext(ctx, "failed to clear write deadline", "error", err)
+		}
+
+		h := c.Writer.Header()
+		h.Set("Content-Type", sse.ContentType)
+		h.Set("Cache-Control", "no-cache")
+		// nginxなどのプロキシにバッファさせない
+		h.Set("X-Accel-Buffering", "no")
+		c.Status(http.StatusOK)
+
+		w := c.Writer
+		if _, err := fmt.Fprintf(w, "retry: %d\n\n", eventRetryMillis); err != nil {
+			return
+		}
+		if sub.Missed || invalidLastID {
+			if err := sse.Encode(w, sse.Event{Event: eventTypeReset, Data: struct{}{}}); err != nil {
+				return
+			}
+		}
+		for _, e := range sub.Replay {
+			if err := writeEvent(w, e); err != nil {
+				return
+			}
+		}
+		w.Flush()
+
+		heartbeat := time.NewTicker(store.Current().Events.HeartbeatInterval)
+		defer heartbeat.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case e, ok := <-sub.Events():
+				if !ok {
+					// 送信が追いつかない場合やサーバーの停止時は切断し、クライアントの再接続に任せる
+					return
+				}
+				if err := writeEvent(w, e); err != nil {
+					return
+				}
+			case <-heartbeat.C:
+				if _, err := io.WriteString(w, ": heartbeat\n\n"); err != nil {
+					return
+				}
+			}
+			w.Flush()
+		}
+	}
+}
+
+// writeEvent は通知をServer-Sent Eventsの形式で書き込みます
+func writeEvent(w io.Writer, e domain.Event) error {
+	return sse.Encode(w, sse.Event{
+		Id:    strconv.FormatUint(e.ID, 10),
+		Event: string(e.Type),
+		Data:  newEventResponse(e),
+	})
+}
+
+func newEventResponse(e domain.Event) EventResponse {
+	res := EventResponse{
+		ID:         e.ID,
+		Type:       string(e.Type),
+		OfficeID:   e.OfficeID,
+		OccurredAt: e.OccurredAt,
+	}
+	switch d := e.Data.(type) {
+	case domain.ZoneAssigned:
+		res.Data = ZoneAssignedEventData(d)
+	case domain.CustomerMoved:
+		res.Data = CustomerMovedEventData(d)
+	case domain.VisitRecorded:
+		res.Data = VisitRecordedEventData{CustomerID: d.CustomerID, SurveyorID: d.SurveyorID, Status: string(d.Status)}
+	default:
+		res.Data = struct{}{}
+	}
+	return res
+}
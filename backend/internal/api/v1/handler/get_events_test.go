@@ -0,0 +1,215 @@
+package handler
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"react-ts/backend/config"
+	"react-ts/backend/internal/domain"
+	"react-ts/backend/internal/event"
+	"react-ts/backend/internal/repository"
+	"react-ts/backend/internal/repository/cached"
+	"react-ts/backend/internal/repository/memtx"
+	"react-ts/backend/internal/usecase"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// sseEvent は受信したServer-Sent Eventsの1件です
+type sseEvent struct {
+	id, event, data, comment string
+}
+
+// readEvents はストリームから n 件のイベント（コメント行を含む）を読み込みます
+func readEvents(t *testing.T, r *bufio.Reader, n int) []sseEvent {
+	t.Helper()
+	var ret []sseEvent
+	var cur sseEvent
+	for len(ret) < n {
+		line, err := r.ReadString('\n')
+		if !assert.NoError(t, err) {
+			return ret
+		}
+		line = strings.TrimSuffix(line, "\n")
+		k, v, _ := strings.Cut(line, ":")
+		switch k {
+		case "":
+			if line == "" {
+				ret = append(ret, cur)
+				cur = sseEvent{}
+			} else {
+				cur.comment = strings.TrimSpace(v)
+			}
+		case "id":
+			cur.id = v
+		case "event":
+			cur.event = v
+		case "data":
+			cur.data = v
+		}
+	}
+	return ret
+}
+
+func newEventsServer(t *testing.T, broker *event.Broker, heartbeat time.Duration) *httptest.Server {
+	gin.SetMode(gin.TestMode)
+	cfg := config.Config{Events: config.EventsConfig{HeartbeatInterval: heartbeat}}
+	store := config.NewStore(cfg, config.Options{}, nil)
+
+	r := gin.New()
+	r.Use(ErrorHandler(store))
+	r.GET("/events", GetEvents(broker, store))
+	srv := httptest.NewServer(r)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func openEvents(t *testing.T, ctx context.Context, url, lastID string) *http.Response {
+	t.Helper()
+	req, _ := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if lastID != "" {
+		req.Header.Set("Last-Event-ID", lastID)
+	}
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { res.Body.Close() })
+	return res
+}
+
+func Test_GetEvents_Stream(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	broker := event.NewBroker("events", 10, 10)
+	srv := newEventsServer(t, broker, time.Hour)
+
+	res := openEvents(t, ctx, srv.URL+"/events?office-id=XX", "")
+	assert := assert.New(t)
+	assert.Equal(http.StatusOK, res.StatusCode)
+	assert.Contains(res.Header.Get("Content-Type"), "text/event-stream")
+	r := bufio.NewReader(res.Body)
+	// 再接続までの時間
+	readEvents(t, r, 1)
+
+	at := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	broker.Publish(ctx, domain.Event{Type: domain.EventVisitRecorded, OfficeID: "YY", Data: domain.VisitRecorded{CustomerID: "C2"}})
+	broker.Publish(ctx, domain.Event{Type: domain.EventZoneAssigned, OfficeID: "XX", OccurredAt: at,
+		Data: domain.ZoneAssigned{WorkZoneID: "Z1", SurveyorID: "000001", PreviousSurveyorID: "000002"}})
+
+	// 他の事業所の通知は配信されない
+	events := readEvents(t, r, 1)
+	assert.Equal("2", events[0].id)
+	assert.Equal("zone.assigned", events[0].event)
+	expected, _ := json.Marshal(EventResponse{
+		ID: 2, Type: "zone.assigned", OfficeID: "XX", OccurredAt: at,
+		Data: ZoneAssignedEventData{WorkZoneID: "Z1", SurveyorID: "000001", PreviousSurveyorID: "000002"},
+	})
+	assert.JSONEq(string(expected), events[0].data)
+
+	// ブローカーが停止したらストリームを終了する
+	broker.Close()
+	_, err := r.ReadString('\n')
+	assert.Error(err)
+}
+
+// 更新系のユースケースが通知した変更が購読者に配信されること
+func Test_GetEvents_UseCase(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	broker := event.NewBroker("events", 10, 10)
+	srv := newEventsServer(t, broker, time.Hour)
+
+	res := openEvents(t, ctx, srv.URL+"/events?office-id=XX", "")
+	r := bufio.NewReader(res.Body)
+	// 再接続までの時間
+	readEvents(t, r, 1)
+
+	tx := memtx.New()
+	customerRepo, workZoneRepo := repository.NewCustomerRepository(), repository.NewWorkZoneRepository()
+	workZoneUC := usecase.NewWorkZoneUseCase(tx, workZoneRepo, cached.Nop{}, broker)
+	customerUC := usecase.NewCustomerUseCase(tx, customerRepo, workZoneRepo, broker)
+	visitUC := usecase.NewVisitUseCase(tx, repository.NewVisitRepository(), customerRepo, repository.NewTrackRepository(0), broker, time.UTC)
+
+	assert := assert.New(t)
+	_, err := workZoneUC.AssignSurveyor(ctx, "Z00003", "000001")
+	assert.NoError(err)
+	assert.NoError(customerUC.MoveCustomer(ctx, domain.CustomerMoved{CustomerID: "C00001", WorkZoneID: "Z00003", Lat: 35.7, Lng: 139.7}))
+	assert.NoError(visitUC.RecordVisit(ctx, domain.Visit{CustomerID: "C00001", SurveyorID: "000001", OfficeID: "XX", Status: domain.CustomerStatusAbsent, RecordedAt: time.Now()}))
+
+	events := readEvents(t, r, 3)
+	var types []string
+	for _, e := range events {
+		types = append(types, e.event)
+	}
+	assert.Equal([]string{"zone.assigned", "customer.moved", "visit.recorded"}, types)
+
+	var data struct {
+		Data ZoneAssignedEventData `json:"data"`
+	}
+	if assert.NoError(json.Unmarshal([]byte(events[0].data), &data)) {
+		assert.Equal(ZoneAssignedEventData{WorkZoneID: "Z00003", SurveyorID: "000001"}, data.Data)
+	}
+}
+
+func Test_GetEvents_Resume(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	broker := event.NewBroker("events", 2, 10)
+	srv := newEventsServer(t, broker, time.Hour)
+	for range 4 {
+		broker.Publish(ctx, domain.Event{Type: domain.EventCustomerMoved, OfficeID: "XX"})
+	}
+
+	tests := []struct {
+		name     string
+		lastID   string
+		expected []string
+	}{
+		{name: "Resume", lastID: "2", expected: []string{"3", "4"}},
+		{name: "Missed", lastID: "1", expected: []string{"reset"}},
+		{name: "Invalid", lastID: "abc", expected: []string{"reset"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := openEvents(t, ctx, srv.URL+"/events?office-id=XX", tt.lastID)
+			r := bufio.NewReader(res.Body)
+			readEvents(t, r, 1)
+
+			var got []string
+			for _, e := range readEvents(t, r, len(tt.expected)) {
+				if e.event == "reset" {
+					got = append(got, e.event)
+				} else {
+					got = append(got, e.id)
+				}
+			}
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func Test_GetEvents_Heartbeat(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	srv := newEventsServer(t, event.NewBroker("events", 10, 10), 10*time.Millisecond)
+
+	res := openEvents(t, ctx, srv.URL+"/events?office-id=XX", "")
+	r := bufio.NewReader(res.Body)
+	readEvents(t, r, 1)
+
+	assert.Equal(t, "heartbeat", readEvents(t, r, 1)[0].comment)
+}
+
+func Test_GetEvents_Validation(t *testing.T) {
+	srv := newEventsServer(t, event.NewBroker("events", 10, 10), time.Hour)
+
+	res := openEvents(t, context.Background(), srv.URL+"/events", "")
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+}
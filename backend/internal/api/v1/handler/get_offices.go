@@ -0,0 +1,37 @@
+package handler
+
+import (
+	"react-ts/backend/internal/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+type GetOfficesResponse struct {
+	ID   string `json:"id" example:"XX"`
+	Name string `json:"name" example:"〇〇事業所"`
+}
+
+// GetOffices godoc
+//
+//	@Summary		事業所のリストを返す
+//	@Tags			offices
+//	@Success		200	{array}		GetOfficesResponse "事業所のリスト"
+//	@Failure		429	{object}	ErrorResponse	"リクエスト数の上限超過"
+//	@Failure		500	{object}	ErrorResponse	"想定外のエラー"
+//	@Failure		504	{object}	ErrorResponse	"処理時間の上限超過"
+//	@Router			/offices [get]
+func GetOffices(uc domain.OfficeUseCase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		md, err := uc.GetOffices(c.Request.Context())
+		if err != nil {
+			c.Error(err).SetType(gin.ErrorTypePublic)
+			return
+		}
+
+		res := make([]GetOfficesResponse, 0, len(md))
+		for _, m := range md {
+			res = append(res, GetOfficesResponse{ID: m.ID, Name: m.Name})
+		}
+		c.JSON(200, res)
+	}
+}
@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"react-ts/backend/internal/domain"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_GetOffices(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/dummy", nil)
+
+	uc := new(MockOfficeUseCase)
+	uc.On("GetOffices", mock.Anything).Return(domain.Offices{{ID: "XX", Name: "〇〇事業所"}}, nil)
+
+	GetOffices(uc)(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, c.Errors)
+	assert.JSONEq(t, `[{"id":"XX","name":"〇〇事業所"}]`, w.Body.String())
+}
+
+type MockOfficeUseCase struct {
+	mock.Mock
+}
+
+func (m *MockOfficeUseCase) GetOffices(ctx context.Context) (domain.Offices, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(domain.Offices), args.Error(1)
+}
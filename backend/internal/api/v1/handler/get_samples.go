@@ -2,7 +2,6 @@ package handler
 
 import (
 	"react-ts/backend/internal/domain"
-	"react-ts/backend/internal/errs"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -29,19 +28,20 @@ type GetSampleResponse struct {
 //	@Param			req	query	GetSampleRequest true	"検索条件"
 //	@Success		200	{object}	GetSampleResponse	"取得結果"
 //	@Failure		400	{object}	ErrorResponse	"不正なリクエスト"
+//	@Failure		429	{object}	ErrorResponse	"リクエスト数の上限超過"
 //	@Failure		500	{object}	ErrorResponse	"想定外のエラー"
+//	@Failure		504	{object}	ErrorResponse	"処理時間の上限超過"
 //	@Router			/samples [get]
 func GetSamples(uc domain.SamplesUseCase) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		var p GetSampleRequest
 		if err := c.ShouldBind(&p); err != nil {
-			details := createValidationDetails(err)
-			err := errs.NewBusinessError(errs.InvalidRequest, details...)
+			err := newInvalidRequestError(c.Request.Context(), err)
 			c.Error(err).SetType(gin.ErrorTypePublic)
 			return
 		}
 
-		md, err := uc.GetSamples()
+		md, err := uc.GetSamples(c.Request.Context())
 		if err != nil {
 			c.Error(err).SetType(gin.ErrorTypePublic)
 			return
@@ -1,12 +1,14 @@
 package handler
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"net/http"
 	"net/http/httptest"
 	"react-ts/backend/internal/domain"
 	"react-ts/backend/internal/errs"
+	"react-ts/backend/internal/i18n"
 	"testing"
 
 	"github.com/gin-gonic/gin"
@@ -48,7 +50,7 @@ func Test_GetSamples_Success(t *testing.T) {
 			c.Request, _ = http.NewRequest("GET", "/dummy?q1=abc", nil)
 
 			uc := new(MockSampleUseCase)
-			uc.On("GetSamples").Return(tt.mockRet, nil)
+			uc.On("GetSamples", mock.Anything).Return(tt.mockRet, nil)
 
 			GetSamples(uc)(c)
 
@@ -116,7 +118,7 @@ func Test_GetSamples_Validation(t *testing.T) {
 
 			// 3. モックの設定とハンドラー実行
 			uc := new(MockSampleUseCase)
-			uc.On("GetSamples").Return(domain.Samples{}, nil)
+			uc.On("GetSamples", mock.Anything).Return(domain.Samples{}, nil)
 
 			GetSamples(uc)(c)
 
@@ -143,6 +145,53 @@ func Test_GetSamples_Validation(t *testing.T) {
 	}
 }
 
+func Test_GetSamples_Violations(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name     string
+		lang     i18n.Lang
+		expected []errs.Violation
+	}{
+		{
+			name: "Japanese",
+			lang: i18n.Japanese,
+			expected: []errs.Violation{
+				{Field: "q1", Rule: "len", Param: "3", RejectedValue: "12", Message: "q1の長さは3文字でなければなりません"},
+				{Field: "email", Rule: "email", RejectedValue: "a@example", Message: "emailは正しいメールアドレスでなければなりません"},
+			},
+		},
+		{
+			name: "English",
+			lang: i18n.English,
+			expected: []errs.Violation{
+				{Field: "q1", Rule: "len", Param: "3", RejectedValue: "12", Message: "q1 must be 3 characters in length"},
+				{Field: "email", Rule: "email", RejectedValue: "a@example", Message: "email must be a valid email address"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest("GET", "/dummy?q1=12&email=a@example", nil)
+			c.Request = c.Request.WithContext(i18n.NewContext(c.Request.Context(), tt.lang))
+
+			GetSamples(new(MockSampleUseCase))(c)
+
+			// 項目名はフォームのパラメータ名で、指定した言語のメッセージであること
+			pe := c.Errors.ByType(gin.ErrorTypePublic).Last()
+			if assert.NotNil(t, pe) {
+				b := pe.Err.(*errs.BusinessError)
+				assert.Equal(t, tt.expected, b.GetViolations())
+				// 互換性のため details にもメッセージを設定すること
+				assert.Equal(t, []string{tt.expected[0].Message, tt.expected[1].Message}, b.GetDetails())
+			}
+		})
+	}
+}
+
 func Test_GetSamples_FailureLogic(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	w := httptest.NewRecorder()
@@ -152,7 +201,7 @@ func Test_GetSamples_FailureLogic(t *testing.T) {
 
 	// ドメインロジックがエラーを返す想定
 	uc := new(MockSampleUseCase)
-	uc.On("GetSamples").Return(domain.Samples{}, errs.NewBusinessError(errs.Exclusion))
+	uc.On("GetSamples", mock.Anything).Return(domain.Samples{}, errs.NewBusinessError(errs.Exclusion))
 
 	GetSamples(uc)(c)
 
@@ -175,7 +224,7 @@ type MockSampleUseCase struct {
 	mock.Mock
 }
 
-func (m *MockSampleUseCase) GetSamples() (domain.Samples, error) {
-	args := m.Called()
+func (m *MockSampleUseCase) GetSamples(ctx context.Context) (domain.Samples, error) {
+	args := m.Called(ctx)
 	return args.Get(0).(domain.Samples), args.Error(1)
 }
@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"react-ts/backend/config"
+	"react-ts/backend/internal/domain"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type GetSurveyorPositionsRequest struct {
+	OfficeID string `form:"office-id" binding:"omitempty,alphanum,max=2" example:"XX"`
+}
+
+// SurveyorPositionsResponse 調査員の最新の位置のGeoJSON（FeatureCollection）の構造体
+type SurveyorPositionsResponse struct {
+	Type     string                    `json:"type" enums:"FeatureCollection" example:"FeatureCollection"`
+	Features []SurveyorPositionFeature `json:"features"`
+}
+
+// SurveyorPositionFeature 調査員の位置のGeoJSON（Feature）の構造体
+type SurveyorPositionFeature struct {
+	Type       string                     `json:"type" enums:"Feature" example:"Feature"`
+	ID         string                     `json:"id" example:"000001"`
+	Geometry   PointGeometry              `json:"geometry"`
+	Properties SurveyorPositionProperties `json:"properties"`
+}
+
+type SurveyorPositionProperties struct {
+	SurveyorID string `json:"surveyorId" example:"000001"`
+	OfficeID   string `json:"officeId" example:"XX"`
+	// 測位の誤差（メートル）
+	Accuracy   float64   `json:"accuracy" example:"12.5"`
+	RecordedAt time.Time `json:"recordedAt" example:"2026-10-19T09:00:00+09:00"`
+	// 位置が一定時間更新されていない（端末の電源断や圏外など）
+	Stale bool `json:"stale" example:"false"`
+}
+
+// GetSurveyorPositions godoc
+//
+//	@Summary		調査員ごとの最新の位置をGeoJSONで返す
+//	@Description	リアルタイムに位置を受け取る場合は /surveyors/positions/live（WebSocket）を使用してください。
+//	@Tags			surveyors
+//	@Produce		application/geo+json
+//	@Param			q	query		GetSurveyorPositionsRequest	true	"検索条件"
+//	@Success		200	{object}	SurveyorPositionsResponse	"調査員の最新の位置"
+//	@Failure		400	{object}	ErrorResponse				"リクエスト形式不正"
+//	@Failure		429	{object}	ErrorResponse				"リクエスト数の上限超過"
+//	@Failure		500	{object}	ErrorResponse				"想定外のエラー"
+//	@Failure		504	{object}	ErrorResponse				"処理時間の上限超過"
+//	@Router			/surveyors/positions [get]
+func GetSurveyorPositions(uc domain.PositionUseCase, store *config.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+
+		var p GetSurveyorPositionsRequest
+		if err := c.ShouldBind(&p); err != nil {
+			err := newInvalidRequestError(c.Request.Context(), err)
+			c.Error(err).SetType(gin.ErrorTypePublic)
+			return
+		}
+
+		md, err := uc.GetLatestPositions(c.Request.Context(), p.OfficeID)
+		if err != nil {
+			c.Error(err).SetType(gin.ErrorTypePublic)
+			return
+		}
+
+		res := newSurveyorPositionsResponse(md, time.Now(), store.Current().Positions.StaleAfter)
+		c.Header("Content-Type", geoJSONContentType)
+		c.JSON(200, res)
+	}
+}
+
+func newSurveyorPositionsResponse(md domain.Positions, now time.Time, staleAfter time.Duration) SurveyorPositionsResponse {
+	res := SurveyorPositionsResponse{
+		Type:     "FeatureCollection",
+		Features: make([]SurveyorPositionFeature, 0, len(md)),
+	}
+	for _, m := range md {
+		res.Features = append(res.Features, newSurveyorPositionFeature(m, now, staleAfter))
+	}
+	return res
+}
+
+func newSurveyorPositionFeature(m domain.Position, now time.Time, staleAfter time.Duration) SurveyorPositionFeature {
+	return SurveyorPositionFeature{
+		Type:     "Feature",
+		ID:       m.SurveyorID,
+		Geometry: newPointGeometry(m.Lat, m.Lng),
+		Properties: SurveyorPositionProperties{
+			SurveyorID: m.SurveyorID,
+			OfficeID:   m.OfficeID,
+			Accuracy:   m.Accuracy,
+			RecordedAt: m.RecordedAt,
+			Stale:      m.IsStale(now, staleAfter),
+		},
+	}
+}
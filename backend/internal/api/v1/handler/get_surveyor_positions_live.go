@@ -0,0 +1,267 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"react-ts/backend/config"
+	"react-ts/backend/internal/domain"
+	"react-ts/backend/internal/event"
+	"react-ts/backend/internal/logging"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/coder/websocket/wsjson"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+const (
+	// 受信する測位結果の最大サイズ（バイト）
+	positionReadLimit = 4 << 10
+	// 1件のメッセージの送信にかける時間の上限
+	positionWriteTimeout = 10 * time.Second
+	// 接続の確認（ping）と、位置が更新されていない調査員の確認を行う間隔
+	positionTickInterval = 15 * time.Second
+)
+
+// 位置の共有のメッセージの種類
+const (
+	positionEventSnapshot = "snapshot"
+	positionEventPosition = "position"
+	positionEventStale    = "stale"
+	positionEventError    = "error"
+)
+
+type GetSurveyorPositionsLiveRequest struct {
+	// TODO 認証を導入したら利用者の所属事業所・調査員IDを使用する
+	OfficeID string `form:"office-id" binding:"required,alphanum,max=2" example:"XX"`
+	// 調査員のアプリが測位結果を送信する場合に指定する（指定しない場合は事業所の調査員の位置を受信する）
+	SurveyorID string `form:"surveyor-id" binding:"omitempty,alphanum,max=6" example:"000001"`
+}
+
+// PositionReportRequest 調査員のアプリが送信する測位結果の構造体
+type PositionReportRequest struct {
+	Lat float64 `json:"lat" binding:"min=-90,max=90" example:"35.681236"`
+	Lng float64 `json:"lng" binding:"min=-180,max=180" example:"139.767125"`
+	// 測位の誤差（メートル）
+	Accuracy   float64   `json:"accuracy" binding:"min=0" example:"12.5"`
+	RecordedAt time.Time `json:"recordedAt" binding:"required" example:"2026-10-19T09:00:00+09:00"`
+}
+
+// PositionMessage サーバーが送信するメッセージの構造体
+//   - snapshot: 接続時の事業所の調査員の最新の位置（positions）
+//   - position: 調査員の位置の更新（position）
+//   - stale: 調査員の位置が一定時間更新されていない（surveyorId）
+//   - error: 送信された測位結果の不正など（error）
+type PositionMessage struct {
+	Event      string                     `json:"event" enums:"snapshot,position,stale,error" example:"position"`
+	Positions  *SurveyorPositionsResponse `json:"positions,omitempty"`
+	Position   *SurveyorPositionFeature   `json:"position,omitempty"`
+	SurveyorID string                     `json:"surveyorId,omitempty" example:"000001"`
+	Error      *ErrorResponse             `json:"error,omitempty"`
+}
+
+// GetSurveyorPositionsLive godoc
+//
+//	@Summary		調査員の位置をWebSocketで共有する
+//	@Description	surveyor-id を指定した場合は調査員のアプリの接続として、PositionReportRequest のJSONを定期的に受信して最新の位置として保存します。
+//	@Description	短い間隔で送信された測位結果は破棄します。不正な測位結果を受信した場合は event が error のメッセージを返します。
+//	@Description	surveyor-id を指定しない場合は事業所の調査員の位置を受信する接続として、PositionMessage のJSONを送信します。
+//	@Description	接続時に snapshot、位置の更新ごとに position、位置が一定時間更新されない調査員について stale を送信します。
+//	@Tags			surveyors
+//	@Param			q	query		GetSurveyorPositionsLiveRequest	true	"接続条件"
+//	@Success		101	{object}	PositionMessage					"WebSocketへの切り替え"
+//	@Failure		400	{object}	ErrorResponse					"リクエスト形式不正"
+//	@Failure		403	{string}	string							"許可されていないオリジン"
+//	@Failure		426	{string}	string							"WebSocketのハンドシェイクではない"
+//	@Failure		429	{object}	ErrorResponse					"リクエスト数の上限超過"
+//	@Router			/surveyors/positions/live [get]
+func GetSurveyorPositionsLive(uc domain.PositionUseCase, broker *event.Broker, store *config.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+
+		var p GetSurveyorPositionsLiveRequest
+		if err := c.ShouldBind(&p); err != nil {
+			err := newInvalidRequestError(c.Request.Context(), err)
+			c.Error(err).SetType(gin.ErrorTypePublic)
+			return
+		}
+
+		ctx := c.Request.Context()
+		// 切り替え後の接続にはサーバーの読み書きの期限（ReadTimeout・WriteTimeout）が残るため解除する
+		rc := http.NewResponseController(c.Writer)
+		if err := errors.Join(rc.SetReadDeadline(time.Time{}), rc.SetWriteDeadline(time.Time{})); err != nil {
+			logging.FromContext(ctx).DebugContext(ctx, "failed to clear deadlines", "error", err)
+		}
+
+		conn, err := websocket.Accept(c.Writer, c.Request, &websocket.AcceptOptions{
+			// ブラウザからの接続はCORSで許可したオリジンのみ受け付ける
+			OriginPatterns: store.Current().CORS.Policy(c.Request.URL.Path).AllowOrigins,
+		})
+		if err != nil {
+			// エラーレスポンスは websocket.Accept が送信済み
+			logging.FromContext(ctx).WarnContext(ctx, "websocket handshake failed", "error", err)
+			return
+		}
+		defer conn.CloseNow()
+
+		// サーバーの停止時は接続を終了させる（切り替え後の接続はサーバーの停止を待たないため）
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		go func() {
+			select {
+			case <-broker.Done():
+				conn.Close(websocket.StatusGoingAway, "server shutting down")
+			case <-ctx.Done():
+			}
+		}()
+
+		if p.SurveyorID != "" {
+			receivePositions(ctx, conn, uc, p)
+		} else {
+			sendPositions(ctx, conn, uc, broker, store, p.OfficeID)
+		}
+	}
+}
+
+// receivePositions は調査員のアプリから測位結果を受信して保存します
+func receivePositions(ctx context.Context, conn *websocket.Conn, uc domain.PositionUseCase, p GetSurveyorPositionsLiveRequest) {
+	conn.SetReadLimit(positionReadLimit)
+	for {
+		var req PositionReportRequest
+		if err := wsjson.Read(ctx, conn, &req); err != nil {
+			logClosed(ctx, err)
+			return
+		}
+		if err := binding.Validator.ValidateStruct(&req); err != nil {
+			if !writePositionError(ctx, conn, newInvalidRequestError(ctx, err)) {
+				return
+			}
+			continue
+		}
+
+		_, err := uc.RecordPosition(ctx, domain.Position{
+			SurveyorID: p.SurveyorID,
+			OfficeID:   p.OfficeID,
+			Lat:        req.Lat,
+			Lng:        req.Lng,
+			Accuracy:   req.Accuracy,
+			RecordedAt: req.RecordedAt,
+		})
+		if err != nil {
+			logging.FromContext(ctx).ErrorContext(ctx, "failed to record position", "error", err)
+			if !writePositionError(ctx, conn, err) {
+				return
+			}
+		}
+	}
+}
+
+// sendPositions は事業所の調査員の位置を送信します
+func sendPositions(ctx context.Context, conn *websocket.Conn, uc domain.PositionUseCase, broker *event.Broker, store *config.Store, officeID string) {
+	sub, err := broker.Subscribe(0, func(e domain.Event) bool {
+		return e.Type == domain.EventPositionUpdated && e.OfficeID == officeID
+	})
+	if err != nil {
+		conn.Close(websocket.StatusGoingAway, "server shutting down")
+		return
+	}
+	defer sub.Close()
+
+	// 受信するメッセージはないため、制御フレーム（ping・close）の処理のみ行う
+	ctx = conn.CloseRead(ctx)
+
+	md, err := uc.GetLatestPositions(ctx, officeID)
+	if err != nil {
+		logging.FromContext(ctx).ErrorContext(ctx, "failed to get positions", "error", err)
+		conn.Close(websocket.StatusInternalError, "failed to get positions")
+		return
+	}
+	staleAfter := store.Current().Positions.StaleAfter
+	snapshot := newSurveyorPositionsResponse(md, time.Now(), staleAfter)
+	if !writePositionMessage(ctx, conn, PositionMessage{Event: positionEventSnapshot, Positions: &snapshot}) {
+		return
+	}
+
+	// 送信した最新の位置（位置が更新されていない調査員の確認に使用する）
+	latest := make(map[string]domain.Position, len(md))
+	for _, m := range md {
+		latest[m.SurveyorID] = m
+	}
+	tick := time.NewTicker(max(min(positionTickInterval, staleAfter/2), time.Millisecond))
+	defer tick.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			logClosed(ctx, context.Cause(ctx))
+			return
+		case e, ok := <-sub.Events():
+			if !ok {
+				// 送信が追いつかない場合やサーバーの停止時は切断し、クライアントの再接続に任せる
+				select {
+				case <-broker.Done():
+					conn.Close(websocket.StatusGoingAway, "server shutting down")
+				default:
+					conn.Close(websocket.StatusTryAgainLater, "subscription closed")
+				}
+				return
+			}
+			m := e.Data.(domain.Position)
+			latest[m.SurveyorID] = m
+			f := newSurveyorPositionFeature(m, time.Now(), store.Current().Positions.StaleAfter)
+			if !writePositionMessage(ctx, conn, PositionMessage{Event: positionEventPosition, Position: &f}) {
+				return
+			}
+		case <-tick.C:
+			staleAfter := store.Current().Positions.StaleAfter
+			now := time.Now()
+			for id, m := range latest {
+				if !m.IsStale(now, staleAfter) {
+					continue
+				}
+				// 通知は1回のみとし、位置が更新されたら再び確認の対象とする
+				delete(latest, id)
+				if !writePositionMessage(ctx, conn, PositionMessage{Event: positionEventStale, SurveyorID: id}) {
+					return
+				}
+			}
+			pingCtx, cancel := context.WithTimeout(ctx, positionWriteTimeout)
+			err := conn.Ping(pingCtx)
+			cancel()
+			if err != nil {
+				logClosed(ctx, err)
+				return
+			}
+		}
+	}
+}
+
+// writePositionError はエラーをエラーレスポンスのメッセージとして送信します
+func writePositionError(ctx context.Context, conn *websocket.Conn, err error) bool {
+	res := newErrorResponse(ctx, err)
+	return writePositionMessage(ctx, conn, PositionMessage{Event: positionEventError, Error: &res})
+}
+
+// writePositionMessage はメッセージを送信します。送信できなかった場合（切断された場合など）は false を返します
+func writePositionMessage(ctx context.Context, conn *websocket.Conn, msg PositionMessage) bool {
+	ctx, cancel := context.WithTimeout(ctx, positionWriteTimeout)
+	defer cancel()
+	if err := wsjson.Write(ctx, conn, msg); err != nil {
+		logClosed(ctx, err)
+		return false
+	}
+	return true
+}
+
+// logClosed は接続が終了した理由をログに出力します（正常な切断の場合は出力しません）
+func logClosed(ctx context.Context, err error) {
+	switch websocket.CloseStatus(err) {
+	case websocket.StatusNormalClosure, websocket.StatusGoingAway:
+		return
+	}
+	if errors.Is(err, context.Canceled) {
+		return
+	}
+	logging.FromContext(ctx).InfoContext(ctx, "websocket closed", "error", err)
+}
@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"context"
+	"net/http/httptest"
+	"react-ts/backend/internal/errs"
+	"react-ts/backend/internal/event"
+	"react-ts/backend/internal/repository"
+	"react-ts/backend/internal/repository/memtx"
+	"react-ts/backend/internal/usecase"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/coder/websocket/wsjson"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func newPositionsServer(t *testing.T, staleAfter time.Duration) (*httptest.Server, *event.Broker) {
+	gin.SetMode(gin.TestMode)
+	broker := event.NewBroker("positions", 0, 10)
+	uc := usecase.NewPositionUseCase(memtx.New(), repository.NewPositionRepository(), repository.NewTrackRepository(0), broker, usecase.PositionOptions{MaxClockSkew: time.Minute, MaxAge: time.Hour}, time.UTC)
+
+	r := gin.New()
+	r.Use(ErrorHandler(newTestStore("json")))
+	r.GET("/positions/live", GetSurveyorPositionsLive(uc, broker, newPositionsTestStore(staleAfter)))
+	srv := httptest.NewServer(r)
+	t.Cleanup(srv.Close)
+	t.Cleanup(broker.Close)
+	return srv, broker
+}
+
+func dialPositions(t *testing.T, ctx context.Context, srv *httptest.Server, query string) *websocket.Conn {
+	t.Helper()
+	url := "ws" + strings.TrimPrefix(srv.URL, "http") + "/positions/live?" + query
+	conn, _, err := websocket.Dial(ctx, url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.CloseNow() })
+	return conn
+}
+
+func readPositionMessage(t *testing.T, ctx context.Context, conn *websocket.Conn) PositionMessage {
+	t.Helper()
+	var msg PositionMessage
+	assert.NoError(t, wsjson.Read(ctx, conn, &msg))
+	return msg
+}
+
+func Test_GetSurveyorPositionsLive(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	srv, _ := newPositionsServer(t, time.Hour)
+	assert := assert.New(t)
+
+	supervisor := dialPositions(t, ctx, srv, "office-id=XX")
+	msg := readPositionMessage(t, ctx, supervisor)
+	assert.Equal(positionEventSnapshot, msg.Event)
+	assert.Empty(msg.Positions.Features)
+
+	surveyor := dialPositions(t, ctx, srv, "office-id=XX&surveyor-id=000001")
+	other := dialPositions(t, ctx, srv, "office-id=YY&surveyor-id=000002")
+	now := time.Now().UTC().Truncate(time.Second)
+	assert.NoError(wsjson.Write(ctx, other, PositionReportRequest{Lat: 34.0, Lng: 135.0, RecordedAt: now}))
+	assert.NoError(wsjson.Write(ctx, surveyor, PositionReportRequest{Lat: 35.68, Lng: 139.76, Accuracy: 10, RecordedAt: now}))
+
+	// 他の事業所の調査員の位置は送信されない
+	msg = readPositionMessage(t, ctx, supervisor)
+	assert.Equal(positionEventPosition, msg.Event)
+	if assert.NotNil(msg.Position) {
+		assert.Equal("000001", msg.Position.ID)
+		assert.Equal([]float64{139.76, 35.68}, msg.Position.Geometry.Coordinates)
+		assert.Equal(now, msg.Position.Properties.RecordedAt)
+	}
+
+	// 新たに接続した場合は最新の位置を受け取る
+	late := dialPositions(t, ctx, srv, "office-id=XX")
+	msg = readPositionMessage(t, ctx, late)
+	if assert.Len(msg.Positions.Features, 1) {
+		assert.Equal("000001", msg.Positions.Features[0].ID)
+	}
+}
+
+func Test_GetSurveyorPositionsLive_InvalidReport(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	srv, _ := newPositionsServer(t, time.Hour)
+
+	surveyor := dialPositions(t, ctx, srv, "office-id=XX&surveyor-id=000001")
+	assert.NoError(t, wsjson.Write(ctx, surveyor, PositionReportRequest{Lat: 100, Lng: 139.76}))
+
+	msg := readPositionMessage(t, ctx, surveyor)
+	assert.Equal(t, positionEventError, msg.Event)
+	if assert.NotNil(t, msg.Error) {
+		assert.Equal(t, errs.InvalidRequest, msg.Error.Code)
+		assert.Len(t, msg.Error.Violations, 2)
+	}
+}
+
+func Test_GetSurveyorPositionsLive_Stale(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	srv, _ := newPositionsServer(t, 100*time.Millisecond)
+
+	supervisor := dialPositions(t, ctx, srv, "office-id=XX")
+	readPositionMessage(t, ctx, supervisor)
+	surveyor := dialPositions(t, ctx, srv, "office-id=XX&surveyor-id=000001")
+	assert.NoError(t, wsjson.Write(ctx, surveyor, PositionReportRequest{Lat: 35.68, Lng: 139.76, RecordedAt: time.Now()}))
+
+	assert.Equal(t, positionEventPosition, readPositionMessage(t, ctx, supervisor).Event)
+	// 位置が更新されないまま一定時間経過すると通知される
+	msg := readPositionMessage(t, ctx, supervisor)
+	assert.Equal(t, positionEventStale, msg.Event)
+	assert.Equal(t, "000001", msg.SurveyorID)
+}
+
+func Test_GetSurveyorPositionsLive_Shutdown(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	srv, broker := newPositionsServer(t, time.Hour)
+
+	supervisor := dialPositions(t, ctx, srv, "office-id=XX")
+	readPositionMessage(t, ctx, supervisor)
+	surveyor := dialPositions(t, ctx, srv, "office-id=XX&surveyor-id=000001")
+
+	broker.Close()
+	for _, conn := range []*websocket.Conn{supervisor, surveyor} {
+		_, _, err := conn.Read(ctx)
+		assert.Equal(t, websocket.StatusGoingAway, websocket.CloseStatus(err))
+	}
+}
@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"react-ts/backend/config"
+	"react-ts/backend/internal/domain"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newPositionsTestStore(staleAfter time.Duration) *config.Store {
+	cfg := config.Config{Positions: config.PositionsConfig{StaleAfter: staleAfter}}
+	return config.NewStore(cfg, config.Options{}, nil)
+}
+
+func Test_GetSurveyorPositions_Success(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	now := time.Now().UTC().Truncate(time.Second)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/dummy?office-id=XX", nil)
+
+	uc := new(MockPositionUseCase)
+	uc.On("GetLatestPositions", mock.Anything, "XX").Return(domain.Positions{
+		{SurveyorID: "000001", OfficeID: "XX", Lat: 35.68, Lng: 139.76, Accuracy: 10, RecordedAt: now},
+		{SurveyorID: "000002", OfficeID: "XX", Lat: 35.69, Lng: 139.70, Accuracy: 20, RecordedAt: now.Add(-time.Hour)},
+	}, nil)
+
+	GetSurveyorPositions(uc, newPositionsTestStore(5*time.Minute))(c)
+
+	assert := assert.New(t)
+	assert.Equal(http.StatusOK, w.Code)
+	assert.Equal("application/geo+json", w.Header().Get("Content-Type"))
+
+	expected, _ := json.Marshal(SurveyorPositionsResponse{
+		Type: "FeatureCollection",
+		Features: []SurveyorPositionFeature{
+			{
+				Type: "Feature", ID: "000001",
+				Geometry:   PointGeometry{Type: "Point", Coordinates: []float64{139.76, 35.68}},
+				Properties: SurveyorPositionProperties{SurveyorID: "000001", OfficeID: "XX", Accuracy: 10, RecordedAt: now},
+			},
+			{
+				Type: "Feature", ID: "000002",
+				Geometry: PointGeometry{Type: "Point", Coordinates: []float64{139.70, 35.69}},
+				// 一定時間更新されていない位置
+				Properties: SurveyorPositionProperties{SurveyorID: "000002", OfficeID: "XX", Accuracy: 20, RecordedAt: now.Add(-time.Hour), Stale: true},
+			},
+		},
+	})
+	assert.JSONEq(string(expected), w.Body.String())
+}
+
+func Test_GetSurveyorPositions_Empty(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/dummy", nil)
+
+	uc := new(MockPositionUseCase)
+	uc.On("GetLatestPositions", mock.Anything, "").Return(domain.Positions(nil), nil)
+
+	GetSurveyorPositions(uc, newPositionsTestStore(5*time.Minute))(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"type":"FeatureCollection","features":[]}`, w.Body.String())
+}
+
+func Test_GetSurveyorPositions_FailureLogic(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/dummy?office-id=XX", nil)
+
+	uc := new(MockPositionUseCase)
+	uc.On("GetLatestPositions", mock.Anything, "XX").Return(domain.Positions(nil), errors.New("error"))
+
+	GetSurveyorPositions(uc, newPositionsTestStore(5*time.Minute))(c)
+
+	assert.Len(t, c.Errors, 1)
+	assert.Equal(t, gin.ErrorTypePublic, c.Errors[0].Type)
+}
+
+type MockPositionUseCase struct {
+	mock.Mock
+}
+
+func (m *MockPositionUseCase) RecordPosition(ctx context.Context, p domain.Position) (bool, error) {
+	args := m.Called(ctx, p)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockPositionUseCase) GetLatestPositions(ctx context.Context, officeID string) (domain.Positions, error) {
+	args := m.Called(ctx, officeID)
+	return args.Get(0).(domain.Positions), args.Error(1)
+}
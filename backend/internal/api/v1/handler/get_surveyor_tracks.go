@@ -0,0 +1,146 @@
+package handler
+
+import (
+	"react-ts/backend/config"
+	"react-ts/backend/internal/domain"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type GetSurveyorTracksPath struct {
+	ID string `uri:"id" binding:"required,alphanum,max=6" example:"000001"`
+}
+
+type GetSurveyorTracksRequest struct {
+	// 軌跡の日付（YYYY-MM-DD）
+	Date string `form:"date" binding:"required,datetime=2006-01-02" example:"2026-10-19"`
+	// 軌跡を簡略化する際に許容する距離（メートル、0の場合は簡略化しない）。省略時は設定値
+	Tolerance *int `form:"tolerance" binding:"omitempty,min=0,max=1000" example:"5"`
+}
+
+// SurveyorTrackResponse 調査員の1日の軌跡のGeoJSON（Feature）の構造体
+type SurveyorTrackResponse struct {
+	Type string `json:"type" enums:"Feature" example:"Feature"`
+	ID   string `json:"id" example:"000001/2026-10-19"`
+	// 点が2つ未満の場合は null
+	Geometry   *LineStringGeometry     `json:"geometry"`
+	Properties SurveyorTrackProperties `json:"properties"`
+}
+
+type SurveyorTrackProperties struct {
+	SurveyorID string `json:"surveyorId" example:"000001"`
+	Date       string `json:"date" example:"2026-10-19"`
+	// 各点の測位の日時（geometryのcoordinatesと同じ順、再生に使用します）
+	CoordTimes []time.Time `json:"coordTimes" example:"2026-10-19T09:00:00+09:00,2026-10-19T09:05:00+09:00"`
+	// 簡略化する前の測位結果の件数
+	RawPointCount int `json:"rawPointCount" example:"120"`
+	// 移動距離（メートル）
+	Distance float64 `json:"distance" example:"5321.4"`
+	// 最初の測位から最後の測位までの時間（秒）
+	DurationSeconds int64 `json:"durationSeconds" example:"28800"`
+	// 滞在を除いた移動の時間（秒）
+	MovingDurationSeconds int64                  `json:"movingDurationSeconds" example:"7200"`
+	Stops                 []SurveyorStopResponse `json:"stops"`
+}
+
+// SurveyorStopResponse 調査員が一定の範囲に一定時間以上とどまっていた滞在の構造体
+type SurveyorStopResponse struct {
+	Lat   float64   `json:"lat" example:"35.681236"`
+	Lng   float64   `json:"lng" example:"139.767125"`
+	Start time.Time `json:"start" example:"2026-10-19T10:00:00+09:00"`
+	End   time.Time `json:"end" example:"2026-10-19T10:15:00+09:00"`
+	// 滞在した時間（秒）
+	DurationSeconds int64 `json:"durationSeconds" example:"900"`
+	// 滞在した位置の近くの担当のお客さま（該当なしの場合は省略）
+	CustomerID string `json:"customerId,omitempty" example:"C00001"`
+}
+
+// GetSurveyorTracks godoc
+//
+//	@Summary		調査員の1日の軌跡をGeoJSONで返す
+//	@Description	軌跡はDouglas–Peuckerのアルゴリズムで簡略化したLineStringで、各点の測位の日時を properties.coordTimes に含みます。
+//	@Description	一定の範囲に一定時間以上とどまっていた滞在を検出し、近くの担当のお客さまと対応付けて properties.stops に含みます。
+//	@Tags			surveyors
+//	@Produce		application/geo+json
+//	@Param			id	path		string						true	"調査員ID"
+//	@Param			q	query		GetSurveyorTracksRequest	true	"検索条件"
+//	@Success		200	{object}	SurveyorTrackResponse		"調査員の軌跡"
+//	@Failure		400	{object}	ErrorResponse				"リクエスト形式不正"
+//	@Failure		429	{object}	ErrorResponse				"リクエスト数の上限超過"
+//	@Failure		500	{object}	ErrorResponse				"想定外のエラー"
+//	@Failure		504	{object}	ErrorResponse				"処理時間の上限超過"
+//	@Router			/surveyors/{id}/tracks [get]
+func GetSurveyorTracks(uc domain.TrackUseCase, store *config.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+
+		var path GetSurveyorTracksPath
+		if err := c.ShouldBindUri(&path); err != nil {
+			err := newInvalidRequestError(c.Request.Context(), err)
+			c.Error(err).SetType(gin.ErrorTypePublic)
+			return
+		}
+		var p GetSurveyorTracksRequest
+		if err := c.ShouldBind(&p); err != nil {
+			err := newInvalidRequestError(c.Request.Context(), err)
+			c.Error(err).SetType(gin.ErrorTypePublic)
+			return
+		}
+
+		// 形式はバリデーションで確認済み
+		date, _ := time.Parse(time.DateOnly, p.Date)
+		tolerance := store.Current().Tracks.SimplifyTolerance
+		if p.Tolerance != nil {
+			tolerance = *p.Tolerance
+		}
+
+		md, err := uc.GetTrack(c.Request.Context(), path.ID, date, float64(tolerance))
+		if err != nil {
+			c.Error(err).SetType(gin.ErrorTypePublic)
+			return
+		}
+
+		c.Header("Content-Type", geoJSONContentType)
+		c.JSON(200, newSurveyorTrackResponse(md))
+	}
+}
+
+func newSurveyorTrackResponse(m domain.Track) SurveyorTrackResponse {
+	date := m.Date.Format(time.DateOnly)
+	res := SurveyorTrackResponse{
+		Type: "Feature",
+		ID:   m.SurveyorID + "/" + date,
+		Properties: SurveyorTrackProperties{
+			SurveyorID:            m.SurveyorID,
+			Date:                  date,
+			CoordTimes:            make([]time.Time, 0, len(m.Points)),
+			RawPointCount:         m.RawPointCount,
+			Distance:              m.Distance,
+			DurationSeconds:       int64(m.Duration / time.Second),
+			MovingDurationSeconds: int64(m.MovingDuration / time.Second),
+			Stops:                 make([]SurveyorStopResponse, 0, len(m.Stops)),
+		},
+	}
+
+	coords := make([][]float64, 0, len(m.Points))
+	for _, p := range m.Points {
+		coords = append(coords, []float64{p.Lng, p.Lat})
+		res.Properties.CoordTimes = append(res.Properties.CoordTimes, p.RecordedAt)
+	}
+	// LineStringは2つ以上の点が必要（RFC 7946）
+	if len(coords) >= 2 {
+		res.Geometry = &LineStringGeometry{Type: "LineString", Coordinates: coords}
+	}
+
+	for _, s := range m.Stops {
+		res.Properties.Stops = append(res.Properties.Stops, SurveyorStopResponse{
+			Lat:             s.Lat,
+			Lng:             s.Lng,
+			Start:           s.Start,
+			End:             s.End,
+			DurationSeconds: int64(s.End.Sub(s.Start) / time.Second),
+			CustomerID:      s.CustomerID,
+		})
+	}
+	return res
+}
@@ -0,0 +1,161 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"react-ts/backend/config"
+	"react-ts/backend/internal/domain"
+	"react-ts/backend/internal/errs"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTracksTestStore(tolerance int) *config.Store {
+	cfg := config.Config{Tracks: config.TracksConfig{SimplifyTolerance: tolerance}}
+	return config.NewStore(cfg, config.Options{}, nil)
+}
+
+func Test_GetSurveyorTracks_Success(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	date := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	start := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/dummy?date=2026-10-19", nil)
+	c.Params = gin.Params{{Key: "id", Value: "000001"}}
+
+	uc := new(MockTrackUseCase)
+	uc.On("GetTrack", mock.Anything, "000001", date, 5.0).Return(domain.Track{
+		SurveyorID: "000001",
+		Date:       date,
+		Points: domain.TrackPoints{
+			{Lat: 35.68, Lng: 139.76, RecordedAt: start},
+			{Lat: 35.69, Lng: 139.70, RecordedAt: start.Add(time.Hour)},
+		},
+		RawPointCount:  120,
+		Distance:       5321.4,
+		Duration:       time.Hour,
+		MovingDuration: 45 * time.Minute,
+		Stops: domain.Stops{
+			{Lat: 35.685, Lng: 139.73, Start: start.Add(10 * time.Minute), End: start.Add(25 * time.Minute), CustomerID: "C00001"},
+		},
+	}, nil)
+
+	GetSurveyorTracks(uc, newTracksTestStore(5))(c)
+
+	assert := assert.New(t)
+	assert.Equal(http.StatusOK, w.Code)
+	assert.Equal("application/geo+json", w.Header().Get("Content-Type"))
+
+	expected, _ := json.Marshal(SurveyorTrackResponse{
+		Type: "Feature",
+		ID:   "000001/2026-10-19",
+		Geometry: &LineStringGeometry{
+			Type:        "LineString",
+			Coordinates: [][]float64{{139.76, 35.68}, {139.70, 35.69}},
+		},
+		Properties: SurveyorTrackProperties{
+			SurveyorID:            "000001",
+			Date:                  "2026-10-19",
+			CoordTimes:            []time.Time{start, start.Add(time.Hour)},
+			RawPointCount:         120,
+			Distance:              5321.4,
+			DurationSeconds:       3600,
+			MovingDurationSeconds: 2700,
+			Stops: []SurveyorStopResponse{
+				{Lat: 35.685, Lng: 139.73, Start: start.Add(10 * time.Minute), End: start.Add(25 * time.Minute), DurationSeconds: 900, CustomerID: "C00001"},
+			},
+		},
+	})
+	assert.JSONEq(string(expected), w.Body.String())
+}
+
+func Test_GetSurveyorTracks_Empty(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	date := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	// 許容する距離を指定した場合は設定値より優先する
+	c.Request, _ = http.NewRequest("GET", "/dummy?date=2026-10-19&tolerance=0", nil)
+	c.Params = gin.Params{{Key: "id", Value: "000001"}}
+
+	uc := new(MockTrackUseCase)
+	uc.On("GetTrack", mock.Anything, "000001", date, 0.0).Return(domain.Track{SurveyorID: "000001", Date: date}, nil)
+
+	GetSurveyorTracks(uc, newTracksTestStore(5))(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{
+		"type":"Feature","id":"000001/2026-10-19","geometry":null,
+		"properties":{"surveyorId":"000001","date":"2026-10-19","coordTimes":[],"rawPointCount":0,
+			"distance":0,"durationSeconds":0,"movingDurationSeconds":0,"stops":[]}
+	}`, w.Body.String())
+}
+
+func Test_GetSurveyorTracks_FailureValidation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name  string
+		id    string
+		query string
+	}{
+		{name: "MissingDate", id: "000001", query: ""},
+		{name: "InvalidDate", id: "000001", query: "date=2026-13-01"},
+		{name: "InvalidTolerance", id: "000001", query: "date=2026-10-19&tolerance=-1"},
+		{name: "InvalidID", id: "0000001", query: "date=2026-10-19"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest("GET", "/dummy?"+tt.query, nil)
+			c.Params = gin.Params{{Key: "id", Value: tt.id}}
+
+			uc := new(MockTrackUseCase)
+			GetSurveyorTracks(uc, newTracksTestStore(5))(c)
+
+			if assert.Len(t, c.Errors, 1) {
+				var be *errs.BusinessError
+				assert.ErrorAs(t, c.Errors[0].Err, &be)
+				assert.Equal(t, errs.InvalidRequest, be.GetCode())
+			}
+			uc.AssertNotCalled(t, "GetTrack", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func Test_GetSurveyorTracks_FailureLogic(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/dummy?date=2026-10-19", nil)
+	c.Params = gin.Params{{Key: "id", Value: "000001"}}
+
+	uc := new(MockTrackUseCase)
+	uc.On("GetTrack", mock.Anything, "000001", mock.Anything, 5.0).Return(domain.Track{}, errors.New("error"))
+
+	GetSurveyorTracks(uc, newTracksTestStore(5))(c)
+
+	assert.Len(t, c.Errors, 1)
+	assert.Equal(t, gin.ErrorTypePublic, c.Errors[0].Type)
+}
+
+type MockTrackUseCase struct {
+	mock.Mock
+}
+
+func (m *MockTrackUseCase) GetTrack(ctx context.Context, surveyorID string, date time.Time, tolerance float64) (domain.Track, error) {
+	args := m.Called(ctx, surveyorID, date, tolerance)
+	return args.Get(0).(domain.Track), args.Error(1)
+}
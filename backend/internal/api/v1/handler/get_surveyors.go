@@ -2,7 +2,6 @@ package handler
 
 import (
 	"react-ts/backend/internal/domain"
-	"react-ts/backend/internal/errs"
 
 	"github.com/gin-gonic/gin"
 )
@@ -22,21 +21,32 @@ type GetSurveyorsResponse struct {
 //	@Tags			surveyors
 //	@Param			q	query		GetSurveyorsRequest	true	"検索条件"
 //	@Success		200	{array}		GetSurveyorsResponse "調査員のリスト"
+//	@Success		304	"変更なし（If-None-Matchが一致）"
 //	@Failure		400	{object}	ErrorResponse "リクエスト形式不正"
+//	@Failure		429	{object}	ErrorResponse	"リクエスト数の上限超過"
 //	@Failure		500	{object}	ErrorResponse	"想定外のエラー"
+//	@Failure		504	{object}	ErrorResponse	"処理時間の上限超過"
 //	@Router			/surveyors [get]
 func GetSurveyors(uc domain.SurveyUseCase) gin.HandlerFunc {
 	return func(c *gin.Context) {
 
 		var p GetSurveyorsRequest
 		if err := c.ShouldBind(&p); err != nil {
-			details := createValidationDetails(err)
-			err := errs.NewBusinessError(errs.InvalidRequest, details...)
+			err := newInvalidRequestError(c.Request.Context(), err)
 			c.Error(err).SetType(gin.ErrorTypePublic)
 			return
 		}
 
-		md, err := uc.GetSurveyors(domain.SurveyorFilter{OfficeID: p.OfficeID})
+		version, err := uc.GetSurveyorsVersion(c.Request.Context())
+		if err != nil {
+			c.Error(err).SetType(gin.ErrorTypePublic)
+			return
+		}
+		if notModified(c, "surveyors", version) {
+			return
+		}
+
+		md, err := uc.GetSurveyors(c.Request.Context(), domain.SurveyorFilter{OfficeID: p.OfficeID})
 		if err != nil {
 			c.Error(err).SetType(gin.ErrorTypePublic)
 			return
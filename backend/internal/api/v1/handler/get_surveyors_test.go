@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"net/http"
@@ -50,7 +51,8 @@ func Test_GetSurveyors_Success(t *testing.T) {
 			c.Request, _ = http.NewRequest("GET", "/dummy?office-id="+tt.oid, nil)
 
 			uc := new(MockSurveyUseCase)
-			uc.On("GetSurveyors",
+			uc.On("GetSurveyorsVersion", mock.Anything).Return("1", nil)
+			uc.On("GetSurveyors", mock.Anything,
 				// mockに渡されるパラメータの検証はここに書く
 				mock.MatchedBy(func(filter domain.SurveyorFilter) bool {
 					return filter.OfficeID == tt.oid
@@ -96,7 +98,8 @@ func Test_GetSurveyors_Validation(t *testing.T) {
 			uc := new(MockSurveyUseCase)
 			if tt.ok {
 				// 失敗ケースでモックを設定しないことで「バリデーションエラー時はUseCaseが呼ばれないこと」も暗黙的に検証できる
-				uc.On("GetSurveyors", mock.Anything).
+				uc.On("GetSurveyorsVersion", mock.Anything).Return("1", nil)
+				uc.On("GetSurveyors", mock.Anything, mock.Anything).
 					Return(domain.Surveyors{}, nil)
 			}
 
@@ -113,6 +116,10 @@ func Test_GetSurveyors_Validation(t *testing.T) {
 					var b *errs.BusinessError
 					if errors.As(pe.Err, &b) {
 						assert.Equal(errs.InvalidRequest, pe.Err.(*errs.BusinessError).GetCode())
+						// 入力項目はクエリパラメータ名で特定できること
+						if assert.Len(b.GetViolations(), 1) {
+							assert.Equal("office-id", b.GetViolations()[0].Field)
+						}
 					} else {
 						assert.Fail("エラーコードが想定外です")
 					}
@@ -131,7 +138,8 @@ func Test_GetSurveyors_FailureLogic(t *testing.T) {
 
 	// ドメインロジックがエラーを返す想定
 	uc := new(MockSurveyUseCase)
-	uc.On("GetSurveyors", mock.Anything).
+	uc.On("GetSurveyorsVersion", mock.Anything).Return("1", nil)
+	uc.On("GetSurveyors", mock.Anything, mock.Anything).
 		Return(domain.Surveyors(nil), errs.NewBusinessError(errs.Exclusion))
 
 	GetSurveyors(uc)(c)
@@ -150,12 +158,62 @@ func Test_GetSurveyors_FailureLogic(t *testing.T) {
 	}
 }
 
+func Test_GetSurveyors_NotModified(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	uc := new(MockSurveyUseCase)
+	uc.On("GetSurveyorsVersion", mock.Anything).Return("1", nil)
+	uc.On("GetSurveyors", mock.Anything, mock.Anything).Return(domain.Surveyors{}, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/dummy", nil)
+	GetSurveyors(uc)(c)
+
+	assert := assert.New(t)
+	assert.Equal(http.StatusOK, w.Code)
+	etag := w.Header().Get("ETag")
+	assert.NotEmpty(etag)
+
+	// バージョンが変わっていない場合は調査員を取得せずに304を返す
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/dummy", nil)
+	c.Request.Header.Set("If-None-Match", etag)
+	GetSurveyors(uc)(c)
+
+	c.Writer.WriteHeaderNow()
+	assert.Equal(http.StatusNotModified, w.Code)
+	assert.Equal(etag, w.Header().Get("ETag"))
+	assert.Empty(c.Errors)
+	uc.AssertNumberOfCalls(t, "GetSurveyors", 1)
+
+	// バージョンが変わった場合は取得し直す
+	uc = new(MockSurveyUseCase)
+	uc.On("GetSurveyorsVersion", mock.Anything).Return("2", nil)
+	uc.On("GetSurveyors", mock.Anything, mock.Anything).Return(domain.Surveyors{}, nil)
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/dummy", nil)
+	c.Request.Header.Set("If-None-Match", etag)
+	GetSurveyors(uc)(c)
+
+	assert.Equal(http.StatusOK, w.Code)
+	assert.NotEqual(etag, w.Header().Get("ETag"))
+	uc.AssertNumberOfCalls(t, "GetSurveyors", 1)
+}
+
 // testify/mockを使用してモック作成
 type MockSurveyUseCase struct {
 	mock.Mock
 }
 
-func (m *MockSurveyUseCase) GetSurveyors(filter domain.SurveyorFilter) (domain.Surveyors, error) {
-	args := m.Called(filter)
+func (m *MockSurveyUseCase) GetSurveyors(ctx context.Context, filter domain.SurveyorFilter) (domain.Surveyors, error) {
+	args := m.Called(ctx, filter)
 	return args.Get(0).(domain.Surveyors), args.Error(1)
 }
+
+func (m *MockSurveyUseCase) GetSurveyorsVersion(ctx context.Context) (string, error) {
+	args := m.Called(ctx)
+	return args.String(0), args.Error(1)
+}
@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"react-ts/backend/config"
+	"react-ts/backend/internal/domain"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type GetVisitAnomaliesRequest struct {
+	// TODO 認証を導入したら利用者の所属事業所で絞り込む
+	OfficeID   string `form:"office-id" binding:"required,alphanum,max=2" example:"XX"`
+	SurveyorID string `form:"surveyor-id" binding:"omitempty,alphanum,max=6" example:"000001"`
+	// 訪問結果を記録した日付（YYYY-MM-DD）
+	Date string `form:"date" binding:"required,datetime=2006-01-02" example:"2026-10-19"`
+	// 対象とする分類（複数指定可、省略時は ON_SITE 以外の全て）
+	Location []string `form:"location" binding:"omitempty,dive,oneof=ON_SITE NEAR FAR NO_FIX" enums:"ON_SITE,NEAR,FAR,NO_FIX" example:"FAR"`
+}
+
+// VisitAnomalyResponse 訪問結果を記録した位置の確認結果の構造体
+type VisitAnomalyResponse struct {
+	ID         string    `json:"id" example:"V00003"`
+	CustomerID string    `json:"customerId" example:"C00003"`
+	SurveyorID string    `json:"surveyorId" example:"000002"`
+	OfficeID   string    `json:"officeId" example:"XX"`
+	Status     string    `json:"status" enums:"NOT_VISITED,VISITED,ABSENT" example:"VISITED"`
+	RecordedAt time.Time `json:"recordedAt" example:"2026-10-19T14:00:00+09:00"`
+	// 記録した位置の分類
+	Location string `json:"location" enums:"ON_SITE,NEAR,FAR,NO_FIX" example:"FAR"`
+	// 記録時の端末の測位結果（測位できなかった場合は null）
+	Fix *VisitFixResponse `json:"fix"`
+	// 記録時の測位結果とお客さまの位置の距離（メートル、測位結果がない場合は null）
+	FixDistance *float64 `json:"fixDistance" example:"1338.2"`
+	// 記録日時の前後の軌跡のうちお客さまの位置に最も近い点の距離（メートル、軌跡がない場合は null）
+	TrackDistance *float64 `json:"trackDistance" example:"1290.5"`
+}
+
+type VisitFixResponse struct {
+	Lat float64 `json:"lat" example:"35.67"`
+	Lng float64 `json:"lng" example:"139.702"`
+	// 測位の誤差（メートル）
+	Accuracy   float64   `json:"accuracy" example:"10"`
+	RecordedAt time.Time `json:"recordedAt" example:"2026-10-19T14:00:00+09:00"`
+}
+
+// GetVisitAnomalies godoc
+//
+//	@Summary		お客さまの位置から離れて記録された訪問結果のリストを返す
+//	@Description	訪問結果を記録した時点の測位結果と前後の軌跡をお客さまの位置と比較し、事業所ごとの距離の条件で現地（ON_SITE）・近隣（NEAR）・遠方（FAR）・確認不可（NO_FIX）に分類します。
+//	@Tags			visits
+//	@Param			q	query		GetVisitAnomaliesRequest	true	"検索条件"
+//	@Success		200	{array}		VisitAnomalyResponse		"訪問結果の確認結果のリスト"
+//	@Failure		400	{object}	ErrorResponse				"リクエスト形式不正"
+//	@Failure		429	{object}	ErrorResponse				"リクエスト数の上限超過"
+//	@Failure		500	{object}	ErrorResponse				"想定外のエラー"
+//	@Failure		504	{object}	ErrorResponse				"処理時間の上限超過"
+//	@Router			/visits/anomalies [get]
+func GetVisitAnomalies(uc domain.VisitUseCase, store *config.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+
+		var p GetVisitAnomaliesRequest
+		if err := c.ShouldBind(&p); err != nil {
+			err := newInvalidRequestError(c.Request.Context(), err)
+			c.Error(err).SetType(gin.ErrorTypePublic)
+			return
+		}
+
+		// 形式はバリデーションで確認済み
+		date, _ := time.Parse(time.DateOnly, p.Date)
+		cfg := store.Current().Geofence
+		policy := cfg.Policy(p.OfficeID)
+		filter := domain.VisitAnomalyFilter{
+			OfficeID:   p.OfficeID,
+			SurveyorID: p.SurveyorID,
+			Date:       date,
+			Geofence: domain.Geofence{
+				OnSiteRadius: float64(policy.OnSiteRadius),
+				NearRadius:   float64(policy.NearRadius),
+				TrackWindow:  cfg.TrackWindow,
+			},
+		}
+		for _, l := range p.Location {
+			filter.Locations = append(filter.Locations, domain.VisitLocation(l))
+		}
+
+		md, err := uc.GetVisitAnomalies(c.Request.Context(), filter)
+		if err != nil {
+			c.Error(err).SetType(gin.ErrorTypePublic)
+			return
+		}
+
+		res := make([]VisitAnomalyResponse, 0, len(md))
+		for _, m := range md {
+			r := VisitAnomalyResponse{
+				ID:            m.ID,
+				CustomerID:    m.CustomerID,
+				SurveyorID:    m.SurveyorID,
+				OfficeID:      m.OfficeID,
+				Status:        string(m.Status),
+				RecordedAt:    m.RecordedAt,
+				Location:      string(m.Location),
+				FixDistance:   m.FixDistance,
+				TrackDistance: m.TrackDistance,
+			}
+			if m.Fix != nil {
+				r.Fix = &VisitFixResponse{Lat: m.Fix.Lat, Lng: m.Fix.Lng, Accuracy: m.Fix.Accuracy, RecordedAt: m.Fix.RecordedAt}
+			}
+			res = append(res, r)
+		}
+		c.JSON(200, res)
+	}
+}
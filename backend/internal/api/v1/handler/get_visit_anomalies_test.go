@@ -0,0 +1,154 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"react-ts/backend/config"
+	"react-ts/backend/internal/domain"
+	"react-ts/backend/internal/errs"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newGeofenceTestStore() *config.Store {
+	cfg := config.Config{Geofence: config.GeofenceConfig{
+		Default:     config.GeofencePolicy{OnSiteRadius: 50, NearRadius: 200},
+		Offices:     map[string]config.GeofencePolicy{"YY": {OnSiteRadius: 100}},
+		TrackWindow: 10 * time.Minute,
+	}}
+	return config.NewStore(cfg, config.Options{}, nil)
+}
+
+func Test_GetVisitAnomalies_Success(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	date := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	recordedAt := time.Date(2026, 10, 19, 5, 0, 0, 0, time.UTC)
+	fixDistance, trackDistance := 1331.5, 1290.0
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/dummy?office-id=YY&date=2026-10-19&location=FAR&location=NO_FIX", nil)
+
+	uc := new(MockVisitUseCase)
+	// 事業所ごとのポリシーで判定する
+	uc.On("GetVisitAnomalies", mock.Anything, domain.VisitAnomalyFilter{
+		OfficeID:  "YY",
+		Date:      date,
+		Locations: []domain.VisitLocation{domain.VisitLocationFar, domain.VisitLocationNoFix},
+		Geofence:  domain.Geofence{OnSiteRadius: 100, NearRadius: 200, TrackWindow: 10 * time.Minute},
+	}).Return(domain.VisitChecks{
+		{
+			Visit: domain.Visit{
+				ID: "V00003", CustomerID: "C00003", SurveyorID: "000002", OfficeID: "YY", Status: domain.CustomerStatusVisited, RecordedAt: recordedAt,
+				Fix: &domain.TrackPoint{Lat: 35.67, Lng: 139.702, Accuracy: 10, RecordedAt: recordedAt},
+			},
+			Location:      domain.VisitLocationFar,
+			FixDistance:   &fixDistance,
+			TrackDistance: &trackDistance,
+		},
+		{
+			Visit:    domain.Visit{ID: "V00004", CustomerID: "C00001", SurveyorID: "000001", OfficeID: "YY", Status: domain.CustomerStatusVisited, RecordedAt: recordedAt},
+			Location: domain.VisitLocationNoFix,
+		},
+	}, nil)
+
+	GetVisitAnomalies(uc, newGeofenceTestStore())(c)
+
+	assert := assert.New(t)
+	assert.Equal(http.StatusOK, w.Code)
+	expected, _ := json.Marshal([]VisitAnomalyResponse{
+		{
+			ID: "V00003", CustomerID: "C00003", SurveyorID: "000002", OfficeID: "YY", Status: "VISITED", RecordedAt: recordedAt, Location: "FAR",
+			Fix:         &VisitFixResponse{Lat: 35.67, Lng: 139.702, Accuracy: 10, RecordedAt: recordedAt},
+			FixDistance: &fixDistance, TrackDistance: &trackDistance,
+		},
+		{ID: "V00004", CustomerID: "C00001", SurveyorID: "000001", OfficeID: "YY", Status: "VISITED", RecordedAt: recordedAt, Location: "NO_FIX"},
+	})
+	assert.JSONEq(string(expected), w.Body.String())
+}
+
+func Test_GetVisitAnomalies_Empty(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/dummy?office-id=XX&date=2026-10-19", nil)
+
+	uc := new(MockVisitUseCase)
+	uc.On("GetVisitAnomalies", mock.Anything, mock.MatchedBy(func(f domain.VisitAnomalyFilter) bool {
+		return f.OfficeID == "XX" && f.Locations == nil && f.Geofence.OnSiteRadius == 50
+	})).Return(domain.VisitChecks(nil), nil)
+
+	GetVisitAnomalies(uc, newGeofenceTestStore())(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `[]`, w.Body.String())
+}
+
+func Test_GetVisitAnomalies_FailureValidation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{name: "MissingOfficeID", query: "date=2026-10-19"},
+		{name: "MissingDate", query: "office-id=XX"},
+		{name: "InvalidLocation", query: "office-id=XX&date=2026-10-19&location=NOWHERE"},
+		{name: "InvalidSurveyorID", query: "office-id=XX&date=2026-10-19&surveyor-id=0000001"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest("GET", "/dummy?"+tt.query, nil)
+
+			uc := new(MockVisitUseCase)
+			GetVisitAnomalies(uc, newGeofenceTestStore())(c)
+
+			if assert.Len(t, c.Errors, 1) {
+				var be *errs.BusinessError
+				assert.ErrorAs(t, c.Errors[0].Err, &be)
+				assert.Equal(t, errs.InvalidRequest, be.GetCode())
+			}
+			uc.AssertNotCalled(t, "GetVisitAnomalies", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func Test_GetVisitAnomalies_FailureLogic(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/dummy?office-id=XX&date=2026-10-19", nil)
+
+	uc := new(MockVisitUseCase)
+	uc.On("GetVisitAnomalies", mock.Anything, mock.Anything).Return(domain.VisitChecks(nil), errors.New("error"))
+
+	GetVisitAnomalies(uc, newGeofenceTestStore())(c)
+
+	assert.Len(t, c.Errors, 1)
+	assert.Equal(t, gin.ErrorTypePublic, c.Errors[0].Type)
+}
+
+type MockVisitUseCase struct {
+	mock.Mock
+}
+
+func (m *MockVisitUseCase) GetVisitAnomalies(ctx context.Context, filter domain.VisitAnomalyFilter) (domain.VisitChecks, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).(domain.VisitChecks), args.Error(1)
+}
+
+func (m *MockVisitUseCase) RecordVisit(ctx context.Context, v domain.Visit) error {
+	args := m.Called(ctx, v)
+	return args.Error(0)
+}
@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"react-ts/backend/internal/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+type GetWorkZonesRequest struct {
+	OfficeID string `form:"office-id" binding:"omitempty,alphanum,max=2" example:"XX"`
+}
+
+type GetWorkZonesResponse struct {
+	ID       string `json:"id" example:"Z00001"`
+	Name     string `json:"name" example:"作業区1"`
+	OfficeID string `json:"officeId" example:"XX"`
+	// 担当の調査員（未割り当ての場合は空）
+	SurveyorID string `json:"surveyorId" example:"000001"`
+}
+
+// GetWorkZones godoc
+//
+//	@Summary		作業区と担当の調査員のリストを返す
+//	@Description	担当の変更は /events の zone.assigned で通知されます。
+//	@Tags			work-zones
+//	@Param			q	query		GetWorkZonesRequest	true	"検索条件"
+//	@Success		200	{array}		GetWorkZonesResponse "作業区のリスト"
+//	@Failure		400	{object}	ErrorResponse "リクエスト形式不正"
+//	@Failure		429	{object}	ErrorResponse	"リクエスト数の上限超過"
+//	@Failure		500	{object}	ErrorResponse	"想定外のエラー"
+//	@Failure		504	{object}	ErrorResponse	"処理時間の上限超過"
+//	@Router			/work-zones [get]
+func GetWorkZones(uc domain.WorkZoneUseCase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var p GetWorkZonesRequest
+		if err := c.ShouldBind(&p); err != nil {
+			err := newInvalidRequestError(c.Request.Context(), err)
+			c.Error(err).SetType(gin.ErrorTypePublic)
+			return
+		}
+
+		md, err := uc.GetWorkZones(c.Request.Context(), domain.WorkZoneFilter{OfficeID: p.OfficeID})
+		if err != nil {
+			c.Error(err).SetType(gin.ErrorTypePublic)
+			return
+		}
+
+		res := make([]GetWorkZonesResponse, 0, len(md))
+		for _, m := range md {
+			res = append(res, GetWorkZonesResponse{
+				ID:         m.ID,
+				Name:       m.Name,
+				OfficeID:   m.OfficeID,
+				SurveyorID: m.SurveyorID,
+			})
+		}
+		c.JSON(200, res)
+	}
+}
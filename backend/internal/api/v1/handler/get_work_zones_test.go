@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"react-ts/backend/internal/domain"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_GetWorkZones(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		q  string
+		ok bool
+	}{
+		{q: "", ok: true},
+		{q: "?office-id=XX", ok: true},
+		{q: "?office-id=123", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run("param:"+tt.q, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest("GET", "/dummy"+tt.q, nil)
+
+			uc := new(MockWorkZoneUseCase)
+			if tt.ok {
+				uc.On("GetWorkZones", mock.Anything, mock.Anything).
+					Return(domain.WorkZones{{ID: "Z00001", Name: "作業区1", OfficeID: "XX", SurveyorID: "000001"}}, nil)
+			}
+
+			GetWorkZones(uc)(c)
+
+			if tt.ok {
+				assert.Equal(t, http.StatusOK, w.Code)
+				assert.JSONEq(t, `[{"id":"Z00001","name":"作業区1","officeId":"XX","surveyorId":"000001"}]`, w.Body.String())
+			} else {
+				assert.NotEmpty(t, c.Errors.ByType(gin.ErrorTypePublic))
+			}
+		})
+	}
+}
+
+type MockWorkZoneUseCase struct {
+	mock.Mock
+}
+
+func (m *MockWorkZoneUseCase) GetWorkZones(ctx context.Context, filter domain.WorkZoneFilter) (domain.WorkZones, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).(domain.WorkZones), args.Error(1)
+}
+
+func (m *MockWorkZoneUseCase) AssignSurveyor(ctx context.Context, workZoneID, surveyorID string) (domain.WorkZone, error) {
+	args := m.Called(ctx, workZoneID, surveyorID)
+	return args.Get(0).(domain.WorkZone), args.Error(1)
+}
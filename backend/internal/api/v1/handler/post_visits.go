@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"net/http"
+	"react-ts/backend/internal/domain"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PostVisitRequest struct {
+	CustomerID string `json:"customerId" binding:"required,alphanum,max=6" example:"C00001"`
+	// TODO 認証を導入したら利用者の調査員ID・所属事業所を使用する
+	SurveyorID string `json:"surveyorId" binding:"required,alphanum,max=6" example:"000001"`
+	OfficeID   string `json:"officeId" binding:"required,alphanum,max=2" example:"XX"`
+	Status     string `json:"status" binding:"required,oneof=VISITED ABSENT" enums:"VISITED,ABSENT" example:"VISITED"`
+	// 訪問結果を記録した日時
+	RecordedAt time.Time `json:"recordedAt" binding:"required" example:"2026-10-19T14:00:00+09:00"`
+	// 記録した時点の端末の測位結果（測位できなかった場合は省略）
+	Fix *PositionReportRequest `json:"fix"`
+}
+
+// PostVisits godoc
+//
+//	@Summary		お客さまへの訪問結果を記録する
+//	@Description	お客さまの訪問状況を更新し、事業所に /events の visit.recorded で通知します。
+//	@Tags			visits
+//	@Accept			json
+//	@Param			body	body	PostVisitRequest	true	"訪問結果"
+//	@Success		204		"記録済み"
+//	@Failure		400		{object}	ErrorResponse	"リクエスト形式不正"
+//	@Failure		429		{object}	ErrorResponse	"リクエスト数の上限超過"
+//	@Failure		500		{object}	ErrorResponse	"想定外のエラー"
+//	@Failure		504		{object}	ErrorResponse	"処理時間の上限超過"
+//	@Router			/visits [post]
+func PostVisits(uc domain.VisitUseCase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+
+		var p PostVisitRequest
+		if err := c.ShouldBindJSON(&p); err != nil {
+			err := newInvalidRequestError(c.Request.Context(), err)
+			c.Error(err).SetType(gin.ErrorTypePublic)
+			return
+		}
+
+		v := domain.Visit{
+			CustomerID: p.CustomerID,
+			SurveyorID: p.SurveyorID,
+			OfficeID:   p.OfficeID,
+			Status:     domain.CustomerStatus(p.Status),
+			RecordedAt: p.RecordedAt,
+		}
+		if p.Fix != nil {
+			v.Fix = &domain.TrackPoint{Lat: p.Fix.Lat, Lng: p.Fix.Lng, Accuracy: p.Fix.Accuracy, RecordedAt: p.Fix.RecordedAt}
+		}
+		if err := uc.RecordVisit(c.Request.Context(), v); err != nil {
+			c.Error(err).SetType(gin.ErrorTypePublic)
+			return
+		}
+		c.Status(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"react-ts/backend/internal/domain"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_PostVisits(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	at := time.Date(2026, 10, 19, 14, 0, 0, 0, time.UTC)
+	visit := domain.Visit{CustomerID: "C00001", SurveyorID: "000001", OfficeID: "XX", Status: domain.CustomerStatusVisited, RecordedAt: at}
+	withFix := visit
+	withFix.Fix = &domain.TrackPoint{Lat: 35.67, Lng: 139.702, Accuracy: 10, RecordedAt: at}
+
+	tests := []struct {
+		name     string
+		body     string
+		expected *domain.Visit
+	}{
+		{name: "NoFix", expected: &visit,
+			body: `{"customerId":"C00001","surveyorId":"000001","officeId":"XX","status":"VISITED","recordedAt":"2026-10-19T14:00:00Z"}`},
+		{name: "Fix", expected: &withFix,
+			body: `{"customerId":"C00001","surveyorId":"000001","officeId":"XX","status":"VISITED","recordedAt":"2026-10-19T14:00:00Z",` +
+				`"fix":{"lat":35.67,"lng":139.702,"accuracy":10,"recordedAt":"2026-10-19T14:00:00Z"}}`},
+		{name: "InvalidStatus",
+			body: `{"customerId":"C00001","surveyorId":"000001","officeId":"XX","status":"NOT_VISITED","recordedAt":"2026-10-19T14:00:00Z"}`},
+		{name: "NoRecordedAt",
+			body: `{"customerId":"C00001","surveyorId":"000001","officeId":"XX","status":"VISITED"}`},
+		{name: "InvalidFix",
+			body: `{"customerId":"C00001","surveyorId":"000001","officeId":"XX","status":"VISITED","recordedAt":"2026-10-19T14:00:00Z",` +
+				`"fix":{"lat":95,"lng":139.702,"accuracy":10,"recordedAt":"2026-10-19T14:00:00Z"}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest("POST", "/dummy", strings.NewReader(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+
+			uc := new(MockVisitUseCase)
+			if tt.expected != nil {
+				uc.On("RecordVisit", mock.Anything, *tt.expected).Return(nil)
+			}
+
+			PostVisits(uc)(c)
+
+			if tt.expected != nil {
+				c.Writer.WriteHeaderNow()
+				assert.Equal(t, http.StatusNoContent, w.Code)
+			} else {
+				assert.NotEmpty(t, c.Errors.ByType(gin.ErrorTypePublic))
+			}
+			uc.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"mime"
+	"react-ts/backend/internal/errs"
+	"strings"
+)
+
+// problemContentType はProblem DetailsのContent-Typeです
+const problemContentType = "application/problem+json"
+
+// ProblemDetails RFC 9457 形式のエラーレスポンスの構造体
+// code・requestId・violations は拡張メンバーとして ErrorResponse と同じ内容を設定します
+type ProblemDetails struct {
+	Type     string `json:"type" example:"/problems/invalid-request"`
+	Title    string `json:"title" example:"リクエストの形式が不正です"`
+	Status   int    `json:"status" example:"400"`
+	Detail   string `json:"detail,omitempty" example:"office-idの長さは最大でも2文字でなければなりません"`
+	Instance string `json:"instance" example:"/v1/surveyors"`
+
+	Code       errs.ErrorCode      `json:"code" example:"INVALID_REQUEST"`
+	RequestID  string              `json:"requestId" example:"3f8c2a6e-1d5b-4c1e-9a7f-2b6d8e0c4a13"`
+	Violations []ViolationResponse `json:"violations,omitempty"`
+}
+
+// newProblemDetails は ErrorResponse からProblem Detailsを生成します
+func newProblemDetails(res ErrorResponse, status int, instance, typeBaseURI string) ProblemDetails {
+	return ProblemDetails{
+		Type:       problemType(typeBaseURI, string(res.Code)),
+		Title:      res.Message,
+		Status:     status,
+		Detail:     strings.Join(res.Details, "\n"),
+		Instance:   instance,
+		Code:       res.Code,
+		RequestID:  res.RequestID,
+		Violations: res.Violations,
+	}
+}
+
+// problemType はエラーコードのtype URIを返します（INVALID_REQUEST → {base}invalid-request）
+func problemType(base, code string) string {
+	return base + strings.ToLower(strings.ReplaceAll(code, "_", "-"))
+}
+
+// wantsProblem はProblem Details形式で応答するかを返します
+// Acceptヘッダーで application/problem+json が指定された場合は true、application/json のみが指定された場合は false、
+// いずれも指定されていない場合は def を返します
+func wantsProblem(accept string, def bool) bool {
+	json := false
+	for part := range strings.SplitSeq(accept, ",") {
+		mt, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil || params["q"] == "0" {
+			continue
+		}
+		switch mt {
+		case problemContentType:
+			return true
+		case "application/json":
+			json = true
+		}
+	}
+	if json {
+		return false
+	}
+	return def
+}
@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"net/http"
+	"react-ts/backend/internal/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PutCustomerLocationPath struct {
+	ID string `uri:"id" binding:"required,alphanum,max=6" example:"C00001"`
+}
+
+type PutCustomerLocationRequest struct {
+	WorkZoneID string  `json:"workZoneId" binding:"required,alphanum,max=6" example:"Z00002"`
+	Lat        float64 `json:"lat" binding:"min=-90,max=90" example:"35.681236"`
+	Lng        float64 `json:"lng" binding:"min=-180,max=180" example:"139.767125"`
+}
+
+// PutCustomerLocation godoc
+//
+//	@Summary		お客さまの位置・作業区を変更する
+//	@Description	移動元と移動先の作業区の事業所に /events の customer.moved で通知します。
+//	@Tags			customers
+//	@Accept			json
+//	@Param			id		path	string						true	"お客さまID"
+//	@Param			body	body	PutCustomerLocationRequest	true	"変更後の位置・作業区"
+//	@Success		204		"変更済み"
+//	@Failure		400		{object}	ErrorResponse	"リクエスト形式不正"
+//	@Failure		404		{object}	ErrorResponse	"お客さままたは作業区が存在しない"
+//	@Failure		429		{object}	ErrorResponse	"リクエスト数の上限超過"
+//	@Failure		500		{object}	ErrorResponse	"想定外のエラー"
+//	@Failure		504		{object}	ErrorResponse	"処理時間の上限超過"
+//	@Router			/customers/{id}/location [put]
+func PutCustomerLocation(uc domain.CustomerUseCase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+
+		var path PutCustomerLocationPath
+		if err := c.ShouldBindUri(&path); err != nil {
+			err := newInvalidRequestError(c.Request.Context(), err)
+			c.Error(err).SetType(gin.ErrorTypePublic)
+			return
+		}
+		var p PutCustomerLocationRequest
+		if err := c.ShouldBindJSON(&p); err != nil {
+			err := newInvalidRequestError(c.Request.Context(), err)
+			c.Error(err).SetType(gin.ErrorTypePublic)
+			return
+		}
+
+		err := uc.MoveCustomer(c.Request.Context(), domain.CustomerMoved{
+			CustomerID: path.ID,
+			WorkZoneID: p.WorkZoneID,
+			Lat:        p.Lat,
+			Lng:        p.Lng,
+		})
+		if err != nil {
+			c.Error(err).SetType(gin.ErrorTypePublic)
+			return
+		}
+		c.Status(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"react-ts/backend/internal/domain"
+	"react-ts/backend/internal/errs"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_PutCustomerLocation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		body string
+		ok   bool
+	}{
+		{body: `{"workZoneId":"Z00002","lat":35.7,"lng":139.7}`, ok: true},
+		{body: `{"lat":35.7,"lng":139.7}`, ok: false},
+		{body: `{"workZoneId":"Z00002","lat":91,"lng":139.7}`, ok: false},
+		{body: `{"workZoneId":"Z00002","lat":35.7,"lng":-181}`, ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.body, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest("PUT", "/dummy", strings.NewReader(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+			c.Params = gin.Params{{Key: "id", Value: "C00001"}}
+
+			uc := new(MockCustomerUseCase)
+			if tt.ok {
+				uc.On("MoveCustomer", mock.Anything, domain.CustomerMoved{CustomerID: "C00001", WorkZoneID: "Z00002", Lat: 35.7, Lng: 139.7}).Return(nil)
+			}
+
+			PutCustomerLocation(uc)(c)
+
+			if tt.ok {
+				c.Writer.WriteHeaderNow()
+				assert.Equal(t, http.StatusNoContent, w.Code)
+			} else {
+				assert.NotEmpty(t, c.Errors.ByType(gin.ErrorTypePublic))
+			}
+			uc.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_PutCustomerLocation_NotFound(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("PUT", "/dummy", strings.NewReader(`{"workZoneId":"Z99999","lat":35.7,"lng":139.7}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = gin.Params{{Key: "id", Value: "C00001"}}
+
+	uc := new(MockCustomerUseCase)
+	uc.On("MoveCustomer", mock.Anything, mock.Anything).Return(errs.NewBusinessError(errs.NotFound))
+
+	PutCustomerLocation(uc)(c)
+
+	if assert.Len(t, c.Errors.ByType(gin.ErrorTypePublic), 1) {
+		assert.ErrorIs(t, c.Errors.Last(), errs.NewBusinessError(errs.NotFound))
+	}
+}
+
+type MockCustomerUseCase struct {
+	mock.Mock
+}
+
+func (m *MockCustomerUseCase) MoveCustomer(ctx context.Context, cm domain.CustomerMoved) error {
+	args := m.Called(ctx, cm)
+	return args.Error(0)
+}
@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"react-ts/backend/internal/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PutWorkZoneSurveyorPath struct {
+	ID string `uri:"id" binding:"required,alphanum,max=6" example:"Z00001"`
+}
+
+type PutWorkZoneSurveyorRequest struct {
+	SurveyorID string `json:"surveyorId" binding:"required,alphanum,max=6" example:"000002"`
+}
+
+// PutWorkZoneSurveyor godoc
+//
+//	@Summary		作業区の担当の調査員を変更する
+//	@Description	担当が変わった場合は作業区の事業所に /events の zone.assigned で通知します。
+//	@Tags			work-zones
+//	@Accept			json
+//	@Param			id		path		string						true	"作業区ID"
+//	@Param			body	body		PutWorkZoneSurveyorRequest	true	"担当の調査員"
+//	@Success		200		{object}	GetWorkZonesResponse		"変更後の作業区"
+//	@Failure		400		{object}	ErrorResponse				"リクエスト形式不正"
+//	@Failure		404		{object}	ErrorResponse				"作業区が存在しない"
+//	@Failure		429		{object}	ErrorResponse				"リクエスト数の上限超過"
+//	@Failure		500		{object}	ErrorResponse				"想定外のエラー"
+//	@Failure		504		{object}	ErrorResponse				"処理時間の上限超過"
+//	@Router			/work-zones/{id}/surveyor [put]
+func PutWorkZoneSurveyor(uc domain.WorkZoneUseCase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+
+		var path PutWorkZoneSurveyorPath
+		if err := c.ShouldBindUri(&path); err != nil {
+			err := newInvalidRequestError(c.Request.Context(), err)
+			c.Error(err).SetType(gin.ErrorTypePublic)
+			return
+		}
+		var p PutWorkZoneSurveyorRequest
+		if err := c.ShouldBindJSON(&p); err != nil {
+			err := newInvalidRequestError(c.Request.Context(), err)
+			c.Error(err).SetType(gin.ErrorTypePublic)
+			return
+		}
+
+		m, err := uc.AssignSurveyor(c.Request.Context(), path.ID, p.SurveyorID)
+		if err != nil {
+			c.Error(err).SetType(gin.ErrorTypePublic)
+			return
+		}
+
+		c.JSON(200, GetWorkZonesResponse{
+			ID:         m.ID,
+			Name:       m.Name,
+			OfficeID:   m.OfficeID,
+			SurveyorID: m.SurveyorID,
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"react-ts/backend/internal/domain"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_PutWorkZoneSurveyor(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		id   string
+		body string
+		ok   bool
+	}{
+		{id: "Z00001", body: `{"surveyorId":"000002"}`, ok: true},
+		{id: "Z00001", body: `{}`, ok: false},
+		{id: "Z00001", body: `{"surveyorId":"0000002"}`, ok: false},
+		{id: "Z-0001", body: `{"surveyorId":"000002"}`, ok: false},
+		{id: "Z00001", body: `not json`, ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id+":"+tt.body, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest("PUT", "/dummy", strings.NewReader(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+			c.Params = gin.Params{{Key: "id", Value: tt.id}}
+
+			uc := new(MockWorkZoneUseCase)
+			if tt.ok {
+				uc.On("AssignSurveyor", mock.Anything, "Z00001", "000002").
+					Return(domain.WorkZone{ID: "Z00001", Name: "作業区1", OfficeID: "XX", SurveyorID: "000002"}, nil)
+			}
+
+			PutWorkZoneSurveyor(uc)(c)
+
+			if tt.ok {
+				assert.Equal(t, http.StatusOK, w.Code)
+				assert.JSONEq(t, `{"id":"Z00001","name":"作業区1","officeId":"XX","surveyorId":"000002"}`, w.Body.String())
+			} else {
+				assert.NotEmpty(t, c.Errors.ByType(gin.ErrorTypePublic))
+			}
+			uc.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"react-ts/backend/config"
+	"react-ts/backend/internal/errs"
+	"react-ts/backend/internal/logging"
+	"react-ts/backend/internal/metrics"
+	"runtime/debug"
+	"syscall"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Recovery はpanicから回復し、errs.Internal のエラーレスポンスを送信して処理を中断するmiddleware。
+// スタックトレースはログにのみ出力し、レスポンスには含めない
+// リクエストIDと言語をレスポンスに含めるため、RequestID・Languageミドルウェアの後に設定してください
+func Recovery(store *config.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer func() {
+			rec := recover()
+			if rec == nil {
+				return
+			}
+			// net/http の規約どおり、意図的な中断はそのまま伝える
+			if rec == http.ErrAbortHandler {
+				panic(rec)
+			}
+
+			ctx := c.Request.Context()
+			route := c.FullPath()
+			if route == "" {
+				route = "unmatched"
+			}
+			metrics.PanicsTotal.WithLabelValues(route).Inc()
+
+			cause, ok := rec.(error)
+			if !ok {
+				cause = fmt.Errorf("%v", rec)
+			}
+			err := errs.NewSystemError("panic recovered", cause)
+			logging.FromContext(ctx).ErrorContext(ctx, "panic recovered",
+				"error", err,
+				"stack", string(debug.Stack()),
+			)
+
+			// クライアントが切断した場合やレスポンスの送信後はエラーレスポンスを送信できない
+			if brokenPipe(cause) || c.Writer.Written() {
+				c.Abort()
+				return
+			}
+			abortWithError(c, store, err)
+		}()
+
+		c.Next()
+	}
+}
+
+// brokenPipe はクライアントとの接続が切断されたことによるエラーかを返します
+func brokenPipe(err error) bool {
+	var ne *net.OpError
+	if !errors.As(err, &ne) {
+		return false
+	}
+	var se *os.SyscallError
+	if errors.As(ne, &se) {
+		return errors.Is(se.Err, syscall.EPIPE) || errors.Is(se.Err, syscall.ECONNRESET)
+	}
+	return false
+}
@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"react-ts/backend/internal/errs"
+	"react-ts/backend/internal/metrics"
+	"react-ts/backend/internal/requestid"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Recovery(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name  string
+		panic any
+	}{
+		{name: "string", panic: "boom"},
+		{name: "error", panic: errors.New("boom")},
+		{name: "SystemError", panic: errs.NewSystemError("boom", nil)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// ログの出力先をバッファに差し替える
+			var buf bytes.Buffer
+			defer slog.SetDefault(slog.Default())
+			slog.SetDefault(slog.New(slog.NewJSONHandler(&buf, nil)))
+
+			w := httptest.NewRecorder()
+			r := gin.New()
+			r.Use(Recovery(newTestStore("json")))
+			r.Use(ErrorHandler(newTestStore("json")))
+			r.GET("/dummy", func(c *gin.Context) {
+				panic(tt.panic)
+			})
+
+			counter := metrics.PanicsTotal.WithLabelValues("/dummy")
+			before := testutil.ToFloat64(counter)
+
+			req, _ := http.NewRequest("GET", "/dummy", nil)
+			req = req.WithContext(requestid.NewContext(req.Context(), "req-0001"))
+			r.ServeHTTP(w, req)
+
+			assert := assert.New(t)
+
+			assert.Equal(http.StatusInternalServerError, w.Code)
+			expectedJson, _ := json.Marshal(ErrorResponse{
+				Code:      errs.Internal,
+				Message:   errs.Internal.GetMessage(),
+				Details:   []string{},
+				RequestID: "req-0001",
+			})
+			assert.JSONEq(string(expectedJson), w.Body.String())
+			assert.Equal(before+1, testutil.ToFloat64(counter))
+
+			// スタックトレースはログにのみ出力されること
+			var rec map[string]any
+			if assert.NoError(json.Unmarshal(buf.Bytes(), &rec)) {
+				assert.Equal("panic recovered", rec["msg"])
+				assert.Contains(rec["stack"], "recovery_test.go")
+			}
+			assert.NotContains(w.Body.String(), "boom")
+		})
+	}
+}
+
+func Test_Recovery_AlreadyWritten(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	w := httptest.NewRecorder()
+	r := gin.New()
+	r.Use(Recovery(newTestStore("json")))
+	r.GET("/dummy", func(c *gin.Context) {
+		c.String(http.StatusOK, "partial")
+		panic("boom")
+	})
+
+	req, _ := http.NewRequest("GET", "/dummy", nil)
+	r.ServeHTTP(w, req)
+
+	// 送信済みのレスポンスにエラーレスポンスを追記しないこと
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "partial", w.Body.String())
+}
@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"react-ts/backend/internal/i18n"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/ja"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	en_translations "github.com/go-playground/validator/v10/translations/en"
+	ja_translations "github.com/go-playground/validator/v10/translations/ja"
+)
+
+// translators は言語ごとのバリデーションエラーのTranslatorです
+var translators map[i18n.Lang]ut.Translator
+
+// 項目名の取得方法は最初のバリデーションの前に設定する必要があるため、パッケージの初期化時に登録する
+func init() {
+	translators = registerTranslations()
+}
+
+// registerTranslations はginのバリデーターに言語ごとのメッセージを登録し、言語ごとのTranslatorを返します
+func registerTranslations() map[i18n.Lang]ut.Translator {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return nil
+	}
+
+	// メッセージの項目名はGoの構造体のフィールド名ではなく、リクエストのパラメータ名を使用する
+	v.RegisterTagNameFunc(fieldName)
+
+	uni := ut.New(ja.New(), ja.New(), en.New())
+	register := map[i18n.Lang]func(*validator.Validate, ut.Translator) error{
+		i18n.Japanese: ja_translations.RegisterDefaultTranslations,
+		i18n.English:  en_translations.RegisterDefaultTranslations,
+	}
+
+	ret := make(map[i18n.Lang]ut.Translator, len(register))
+	for lang, fn := range register {
+		trans, _ := uni.GetTranslator(string(lang))
+		if err := fn(v, trans); err != nil {
+			panic(err)
+		}
+		ret[lang] = trans
+	}
+	return ret
+}
+
+// fieldName はform・jsonタグの名前を返します。タグがない場合はフィールド名を返します
+func fieldName(f reflect.StructField) string {
+	for _, tag := range []string{"form", "json", "uri"} {
+		name := strings.Split(f.Tag.Get(tag), ",")[0]
+		if name == "-" {
+			return ""
+		}
+		if name != "" {
+			return name
+		}
+	}
+	return f.Name
+}
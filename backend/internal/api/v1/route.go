@@ -1,8 +1,11 @@
 package v1
 
 import (
+	"react-ts/backend/config"
+	"react-ts/backend/internal/api/middleware"
 	"react-ts/backend/internal/api/v1/handler"
 	"react-ts/backend/internal/bootstrap"
+	"react-ts/backend/internal/ratelimit"
 
 	"github.com/gin-gonic/gin"
 )
@@ -10,11 +13,23 @@ import (
 // @title	react-ts backend API
 // @version	1.0
 // @BasePath	/v1
-func Route(r *gin.Engine, cp *bootstrap.Components) {
+func Route(r *gin.Engine, store *config.Store, cp *bootstrap.Components) {
 
 	v1 := r.Group("/v1")
 
-	v1.Use(handler.ErrorHandler())
+	v1.Use(handler.ErrorHandler(store))
+	v1.Use(middleware.RateLimit(store, ratelimit.NewMemoryStore()))
 	v1.GET("/surveyors", handler.GetSurveyors(cp.SurveyUC))
+	v1.GET("/surveyors/positions", handler.GetSurveyorPositions(cp.PositionUC, store))
+	v1.GET("/surveyors/positions/live", handler.GetSurveyorPositionsLive(cp.PositionUC, cp.Positions, store))
+	v1.GET("/surveyors/:id/tracks", handler.GetSurveyorTracks(cp.TrackUC, store))
+	v1.GET("/offices", handler.GetOffices(cp.OfficeUC))
+	v1.GET("/work-zones", handler.GetWorkZones(cp.WorkZoneUC))
+	v1.PUT("/work-zones/:id/surveyor", handler.PutWorkZoneSurveyor(cp.WorkZoneUC))
+	v1.PUT("/customers/:id/location", handler.PutCustomerLocation(cp.CustomerUC))
+	v1.POST("/visits", handler.PostVisits(cp.VisitUC))
+	v1.GET("/visits/anomalies", handler.GetVisitAnomalies(cp.VisitUC, store))
 	v1.GET("/samples", handler.GetSamples(cp.SampleUC))
+	v1.GET("/error-codes", handler.GetErrorCodes(store))
+	v1.GET("/events", handler.GetEvents(cp.Events, store))
 }
@@ -1,27 +1,166 @@
 package bootstrap
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"react-ts/backend/config"
 	"react-ts/backend/internal/domain"
+	"react-ts/backend/internal/event"
+	"react-ts/backend/internal/health"
 	"react-ts/backend/internal/repository"
+	"react-ts/backend/internal/repository/cached"
+	"react-ts/backend/internal/repository/memtx"
 	"react-ts/backend/internal/usecase"
+	"time"
 )
 
 type Components struct {
-	SampleUC   domain.SamplesUseCase
-	SampleRepo domain.SampleRepository
-	SurveyUC   domain.SurveyUseCase
-	SurveyRepo domain.SurveyRepository
+	SampleUC     domain.SamplesUseCase
+	SampleRepo   domain.SampleRepository
+	SurveyUC     domain.SurveyUseCase
+	SurveyRepo   domain.SurveyRepository
+	OfficeUC     domain.OfficeUseCase
+	OfficeRepo   domain.OfficeRepository
+	CustomerUC   domain.CustomerUseCase
+	CustomerRepo domain.CustomerRepository
+	WorkZoneUC   domain.WorkZoneUseCase
+	WorkZoneRepo domain.WorkZoneRepository
+	StatisticsUC domain.StatisticsUseCase
+	PositionUC   domain.PositionUseCase
+	PositionRepo domain.PositionRepository
+	TrackUC      domain.TrackUseCase
+	TrackRepo    domain.TrackRepository
+	VisitUC      domain.VisitUseCase
+	VisitRepo    domain.VisitRepository
+	// 複数のリポジトリにまたがる更新を1つのトランザクションで実行する
+	TxManager domain.TxManager
+	// 更新系のユースケースが更新後に参照結果のキャッシュを破棄する
+	SurveyCache   domain.CacheInvalidator
+	OfficeCache   domain.CacheInvalidator
+	WorkZoneCache domain.CacheInvalidator
+	// 更新系のユースケースが変更を通知し、/v1/events の接続に配信する
+	Events *event.Broker
+	// 調査員の位置の更新を /v1/surveyors/positions/live の接続に配信する（変更通知とは頻度が異なるため分ける）
+	Positions *event.Broker
+
+	// 各サブシステムが自身のヘルスチェックを登録するレジストリ
+	Health *health.Registry
+
+	// 停止時に解放するリソース（登録の逆順に解放する）
+	closers []closer
 }
 
-func NewComponents() *Components {
+type closer struct {
+	name string
+	fn   func(ctx context.Context) error
+}
+
+// ヘルスチェックのデフォルトのタイムアウト
+const healthCheckTimeout = 3 * time.Second
+
+// NewComponents は設定に従って各コンポーネントを生成し、依存関係を設定します
+func NewComponents(cfg config.Config) *Components {
 	sampleRepo := repository.NewSamplesRepository()
 	sampleUC := usecase.NewSamplesUseCase(sampleRepo)
-	surveyRepo := repository.NewSurveyRepository()
+	var surveyRepo domain.SurveyRepository = repository.NewSurveyRepository()
+	customerRepo := repository.NewCustomerRepository()
+	var officeRepo domain.OfficeRepository = repository.NewOfficeRepository()
+	var workZoneRepo domain.WorkZoneRepository = repository.NewWorkZoneRepository()
+
+	// 地図を表示するたびに参照される調査員・事業所・作業区はキャッシュする
+	// 業務メトリクスの集計（未割り当ての作業区の数）はキャッシュの対象外のため、常に最新の値を集計する
+	var surveyCache, officeCache, workZoneCache domain.CacheInvalidator = cached.Nop{}, cached.Nop{}, cached.Nop{}
+	if cfg.Cache.Enabled {
+		c := cached.NewSurveyRepository(surveyRepo, cfg.Cache)
+		surveyRepo, surveyCache = c, c
+		o := cached.NewOfficeRepository(officeRepo, cfg.Cache)
+		officeRepo, officeCache = o, o
+		w := cached.NewWorkZoneRepository(workZoneRepo, cfg.Cache)
+		workZoneRepo, workZoneCache = w, w
+	}
+
 	surveyUC := usecase.NewSurveyUseCase(surveyRepo)
-	return &Components{
-		SampleRepo: sampleRepo,
-		SampleUC:   sampleUC,
-		SurveyRepo: surveyRepo,
-		SurveyUC:   surveyUC,
+	officeUC := usecase.NewOfficeUseCase(officeRepo)
+	statisticsUC := usecase.NewStatisticsUseCase(customerRepo, workZoneRepo)
+
+	// トランザクションで扱うデータは最新の位置・軌跡（メモリ上）のみのため、メモリ上のTxManagerを使用する
+	// メモリ上のリポジトリは domain.OnRollback で変更を取り消すため、repository.NewTxManager に切り替えてもそのまま参加できる
+	// TODO データベースに接続したら repository.NewTxManager に切り替える
+	txManager := memtx.New()
+
+	events := event.NewBroker("events", cfg.Events.ReplaySize, cfg.Events.BufferSize)
+	// 位置は接続時に最新の位置を取得するため再送しない
+	positions := event.NewBroker("positions", 0, cfg.Positions.BufferSize)
+	positionRepo := repository.NewPositionRepository()
+	trackRepo := repository.NewTrackRepository(cfg.Tracks.Retention)
+	positionUC := usecase.NewPositionUseCase(txManager, positionRepo, trackRepo, positions, usecase.PositionOptions{
+		MinInterval:  cfg.Positions.MinInterval,
+		MaxClockSkew: cfg.Positions.MaxClockSkew,
+		MaxAge:       cfg.Positions.MaxAge,
+	}, cfg.Tracks.Location())
+	trackUC := usecase.NewTrackUseCase(trackRepo, customerRepo, usecase.TrackOptions{
+		StopRadius:          float64(cfg.Tracks.StopRadius),
+		StopMinDuration:     cfg.Tracks.StopMinDuration,
+		CustomerMatchRadius: float64(cfg.Tracks.CustomerMatchRadius),
+	})
+	visitRepo := repository.NewVisitRepository()
+	visitUC := usecase.NewVisitUseCase(txManager, visitRepo, customerRepo, trackRepo, events, cfg.Tracks.Location())
+	workZoneUC := usecase.NewWorkZoneUseCase(txManager, workZoneRepo, workZoneCache, events)
+	customerUC := usecase.NewCustomerUseCase(txManager, customerRepo, workZoneRepo, events)
+
+	hc := health.NewRegistry(healthCheckTimeout)
+	hc.Register("database", 0, repository.Ping)
+	// 変更通知・位置の共有はサーバーの停止処理の開始時に停止するため、以降は振り分けられないようにする
+	hc.Register("events", 0, events.Ping)
+	hc.Register("positions", 0, positions.Ping)
+
+	cp := &Components{
+		SampleRepo:    sampleRepo,
+		SampleUC:      sampleUC,
+		SurveyRepo:    surveyRepo,
+		SurveyUC:      surveyUC,
+		OfficeUC:      officeUC,
+		OfficeRepo:    officeRepo,
+		CustomerUC:    customerUC,
+		CustomerRepo:  customerRepo,
+		WorkZoneUC:    workZoneUC,
+		WorkZoneRepo:  workZoneRepo,
+		StatisticsUC:  statisticsUC,
+		PositionUC:    positionUC,
+		PositionRepo:  positionRepo,
+		TrackUC:       trackUC,
+		TrackRepo:     trackRepo,
+		VisitUC:       visitUC,
+		VisitRepo:     visitRepo,
+		TxManager:     txManager,
+		SurveyCache:   surveyCache,
+		OfficeCache:   officeCache,
+		WorkZoneCache: workZoneCache,
+		Events:        events,
+		Positions:     positions,
+		Health:        hc,
+	}
+	cp.AddCloser("database", repository.Close)
+
+	return cp
+}
+
+// AddCloser は停止時に解放するリソースを登録します
+// 後から登録したもの（依存する側）から先に解放されるよう、依存先より後に登録してください
+func (cp *Components) AddCloser(name string, fn func(ctx context.Context) error) {
+	cp.closers = append(cp.closers, closer{name: name, fn: fn})
+}
+
+// Close は登録されたリソースを登録の逆順に解放します
+// 途中でエラーが発生しても残りのリソースの解放を続け、全てのエラーをまとめて返します
+func (cp *Components) Close(ctx context.Context) error {
+	var errs []error
+	for i := len(cp.closers) - 1; i >= 0; i-- {
+		c := cp.closers[i]
+		if err := c.fn(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("failed to close %s: %w", c.name, err))
+		}
 	}
+	return errors.Join(errs...)
 }
@@ -0,0 +1,40 @@
+package buildinfo
+
+import (
+	"runtime"
+	"runtime/debug"
+)
+
+// ビルド時に -ldflags で埋め込まれる値
+//
+//	go build -ldflags "-X react-ts/backend/internal/buildinfo.Commit=$(git rev-parse HEAD) -X react-ts/backend/internal/buildinfo.BuildTime=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+var (
+	Commit    string
+	BuildTime string
+)
+
+// Info はビルド情報です
+type Info struct {
+	Commit    string `json:"commit" example:"6fd9347"`
+	BuildTime string `json:"buildTime" example:"2026-01-01T00:00:00Z"`
+	GoVersion string `json:"goVersion" example:"go1.25.0"`
+}
+
+// Get はビルド情報を返します
+// コミットがldflagsで埋め込まれていない場合は、Goが記録したVCS情報を使用します
+func Get() Info {
+	info := Info{
+		Commit:    Commit,
+		BuildTime: BuildTime,
+		GoVersion: runtime.Version(),
+	}
+
+	if bi, ok := debug.ReadBuildInfo(); ok {
+		for _, s := range bi.Settings {
+			if s.Key == "vcs.revision" && info.Commit == "" {
+				info.Commit = s.Value
+			}
+		}
+	}
+	return info
+}
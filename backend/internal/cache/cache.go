@@ -0,0 +1,171 @@
+// Package cache はリポジトリの参照結果をメモリにキャッシュするLRUキャッシュです。
+//
+// エントリー数の上限（LRU）と有効期間（TTL）を持ち、同じキーの読み込みが同時に要求された場合は1回だけ読み込みます。
+package cache
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"react-ts/backend/internal/metrics"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// Cache はキー K に対する値 V をキャッシュします
+// 返す値はキャッシュと共有されるため、呼び出し元で変更しないでください
+type Cache[K comparable, V any] struct {
+	// メトリクスのラベルに使用する名前
+	name string
+	size int
+	ttl  time.Duration
+	// 読み込みの上限時間
+	loadTimeout time.Duration
+	now         func() time.Time
+
+	mu      sync.Mutex
+	entries map[K]*list.Element
+	// 参照された順（先頭が最も新しい）
+	order *list.List
+	// 破棄されるたびに増やし、破棄される前に読み込みを開始した値をキャッシュしないようにする
+	gen uint64
+
+	group singleflight.Group
+}
+
+type entry[K comparable, V any] struct {
+	key     K
+	value   V
+	expires time.Time
+}
+
+// New はキャッシュを生成します
+// loadTimeout は読み込みの上限時間です（呼び出し元のキャンセルを伝えないため、応答しないデータストアの読み込みが残り続けないよう制限します）
+func New[K comparable, V any](name string, size int, ttl, loadTimeout time.Duration) *Cache[K, V] {
+	return &Cache[K, V]{
+		name:        name,
+		size:        size,
+		ttl:         ttl,
+		loadTimeout: loadTimeout,
+		now:         time.Now,
+		entries:     map[K]*list.Element{},
+		order:       list.New(),
+	}
+}
+
+// Get はキャッシュされた値を返します。キャッシュされていない場合は load で読み込んでキャッシュします
+//
+// 同じキーの読み込み中に呼び出された場合は、その読み込みの結果を待ちます。
+// 読み込みは待っている全ての呼び出し元で共有されるため、ctx のキャンセルは読み込みに伝えず（上限時間のみ設定します）、
+// 呼び出し元は ctx がキャンセルされた時点で待つのをやめます。
+// 読み込みに失敗した場合はキャッシュしません。
+func (c *Cache[K, V]) Get(ctx context.Context, key K, load func(ctx context.Context) (V, error)) (V, error) {
+	if v, ok := c.get(key); ok {
+		metrics.CacheRequestsTotal.WithLabelValues(c.name, "hit").Inc()
+		return v, nil
+	}
+	metrics.CacheRequestsTotal.WithLabelValues(c.name, "miss").Inc()
+
+	c.mu.Lock()
+	gen := c.gen
+	c.mu.Unlock()
+
+	// 破棄後の呼び出しが破棄前に開始した読み込みを共有しないよう、キーに世代を含める
+	ch := c.group.DoChan(fmt.Sprintf("%d/%#v", gen, key), func() (any, error) {
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), c.loadTimeout)
+		defer cancel()
+		v, err := load(ctx)
+		if err == nil {
+			c.add(key, v, gen)
+		}
+		return v, err
+	})
+
+	var zero V
+	select {
+	case <-ctx.Done():
+		return zero, ctx.Err()
+	case r := <-ch:
+		if r.Err != nil {
+			return zero, r.Err
+		}
+		return r.Val.(V), nil
+	}
+}
+
+// Invalidate は指定したキーのキャッシュを破棄します
+func (c *Cache[K, V]) Invalidate(keys ...K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.gen++
+	for _, key := range keys {
+		if el, ok := c.entries[key]; ok {
+			c.remove(el)
+		}
+	}
+}
+
+// InvalidateAll は全てのキャッシュを破棄します
+func (c *Cache[K, V]) InvalidateAll() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.gen++
+	clear(c.entries)
+	c.order.Init()
+}
+
+// Len はキャッシュされているエントリー数（有効期間が過ぎたものを含む）を返します
+func (c *Cache[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+func (c *Cache[K, V]) get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var zero V
+	el, ok := c.entries[key]
+	if !ok {
+		return zero, false
+	}
+	e := el.Value.(*entry[K, V])
+	if !c.now().Before(e.expires) {
+		c.remove(el)
+		return zero, false
+	}
+	c.order.MoveToFront(el)
+	return e.value, true
+}
+
+// add は値をキャッシュします。読み込みを開始してから破棄された場合（世代が異なる場合）はキャッシュしません
+func (c *Cache[K, V]) add(key K, v V, gen uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if gen != c.gen {
+		return
+	}
+	e := &entry[K, V]{key: key, value: v, expires: c.now().Add(c.ttl)}
+	if el, ok := c.entries[key]; ok {
+		el.Value = e
+		c.order.MoveToFront(el)
+		return
+	}
+	c.entries[key] = c.order.PushFront(e)
+
+	for c.order.Len() > c.size {
+		c.remove(c.order.Back())
+		metrics.CacheEvictionsTotal.WithLabelValues(c.name).Inc()
+	}
+}
+
+func (c *Cache[K, V]) remove(el *list.Element) {
+	c.order.Remove(el)
+	delete(c.entries, el.Value.(*entry[K, V]).key)
+}
@@ -0,0 +1,172 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// loader は読み込み回数を数える読み込み処理です
+type loader struct {
+	calls atomic.Int32
+}
+
+func (l *loader) load(v string) func(ctx context.Context) (string, error) {
+	return func(ctx context.Context) (string, error) {
+		l.calls.Add(1)
+		return v, nil
+	}
+}
+
+func Test_Cache_Get(t *testing.T) {
+	ctx := context.Background()
+	c := New[string, string]("test", 10, time.Minute, time.Minute)
+	var l loader
+
+	v, err := c.Get(ctx, "a", l.load("1"))
+	assert.NoError(t, err)
+	assert.Equal(t, "1", v)
+
+	// 2回目はキャッシュから返す
+	v, _ = c.Get(ctx, "a", l.load("2"))
+	assert.Equal(t, "1", v)
+	assert.EqualValues(t, 1, l.calls.Load())
+
+	// 失敗した場合はキャッシュしない
+	_, err = c.Get(ctx, "b", func(ctx context.Context) (string, error) { return "", errors.New("failed") })
+	assert.Error(t, err)
+	assert.Equal(t, 1, c.Len())
+}
+
+func Test_Cache_TTL(t *testing.T) {
+	ctx := context.Background()
+	c := New[string, string]("test", 10, time.Minute, time.Minute)
+	now := time.Now()
+	c.now = func() time.Time { return now }
+	var l loader
+
+	c.Get(ctx, "a", l.load("1"))
+	now = now.Add(time.Minute)
+	v, _ := c.Get(ctx, "a", l.load("2"))
+
+	assert.Equal(t, "2", v)
+	assert.EqualValues(t, 2, l.calls.Load())
+}
+
+func Test_Cache_LRU(t *testing.T) {
+	ctx := context.Background()
+	c := New[string, string]("test", 2, time.Minute, time.Minute)
+	var l loader
+
+	c.Get(ctx, "a", l.load("a"))
+	c.Get(ctx, "b", l.load("b"))
+	// a を参照したため、最も長く参照されていない b が破棄される
+	c.Get(ctx, "a", l.load("a"))
+	c.Get(ctx, "c", l.load("c"))
+	assert.Equal(t, 2, c.Len())
+	assert.EqualValues(t, 3, l.calls.Load())
+
+	c.Get(ctx, "a", l.load("a"))
+	assert.EqualValues(t, 3, l.calls.Load())
+	c.Get(ctx, "b", l.load("b"))
+	assert.EqualValues(t, 4, l.calls.Load())
+}
+
+func Test_Cache_Invalidate(t *testing.T) {
+	ctx := context.Background()
+	c := New[string, string]("test", 10, time.Minute, time.Minute)
+	var l loader
+
+	c.Get(ctx, "a", l.load("1"))
+	c.Get(ctx, "b", l.load("1"))
+	c.Invalidate("a")
+	v, _ := c.Get(ctx, "a", l.load("2"))
+	assert.Equal(t, "2", v)
+
+	c.InvalidateAll()
+	assert.Equal(t, 0, c.Len())
+}
+
+// 同じキーの同時の読み込みは1回にまとめられること
+func Test_Cache_Singleflight(t *testing.T) {
+	ctx := context.Background()
+	c := New[string, string]("test", 10, time.Minute, time.Minute)
+	var calls atomic.Int32
+	release := make(chan struct{})
+	load := func(ctx context.Context) (string, error) {
+		calls.Add(1)
+		<-release
+		return "1", nil
+	}
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Go(func() {
+			v, err := c.Get(ctx, "a", load)
+			assert.NoError(t, err)
+			assert.Equal(t, "1", v)
+		})
+	}
+	// 全ての呼び出しが読み込みを待つまで待機する
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.EqualValues(t, 1, calls.Load())
+}
+
+// 読み込み中に破棄された場合、読み込んだ値（破棄前のデータの可能性がある）はキャッシュしないこと
+func Test_Cache_InvalidateWhileLoading(t *testing.T) {
+	ctx := context.Background()
+	c := New[string, string]("test", 10, time.Minute, time.Minute)
+	var l loader
+
+	v, _ := c.Get(ctx, "a", func(ctx context.Context) (string, error) {
+		c.InvalidateAll()
+		return "stale", nil
+	})
+	assert.Equal(t, "stale", v)
+
+	v, _ = c.Get(ctx, "a", l.load("fresh"))
+	assert.Equal(t, "fresh", v)
+}
+
+// 呼び出し元のcontextがキャンセルされた場合は待つのをやめること
+func Test_Cache_Canceled(t *testing.T) {
+	c := New[string, string]("test", 10, time.Minute, time.Minute)
+	ctx, cancel := context.WithCancel(context.Background())
+	release := make(chan struct{})
+	defer close(release)
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+	_, err := c.Get(ctx, "a", func(ctx context.Context) (string, error) {
+		// 読み込みにはキャンセルが伝わらない
+		assert.NoError(t, ctx.Err())
+		// 上限時間は設定される
+		_, ok := ctx.Deadline()
+		assert.True(t, ok)
+		<-release
+		return "1", nil
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func Test_Cache_LoadTimeout(t *testing.T) {
+	c := New[string, string]("test", 10, time.Minute, 10*time.Millisecond)
+
+	// 応答しない読み込みは上限時間で打ち切られ、キャッシュしない
+	_, err := c.Get(context.Background(), "a", func(ctx context.Context) (string, error) {
+		<-ctx.Done()
+		return "", ctx.Err()
+	})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, 0, c.Len())
+}
@@ -0,0 +1,9 @@
+package domain
+
+import "context"
+
+// CacheInvalidator はリポジトリがキャッシュした参照結果を破棄します
+// 更新系のユースケースは、更新したデータを参照するリポジトリのキャッシュを更新後（トランザクションのコミット後）に破棄してください
+type CacheInvalidator interface {
+	InvalidateCache(ctx context.Context)
+}
@@ -0,0 +1,41 @@
+package domain
+
+import "context"
+
+// お客さまの訪問状況
+type CustomerStatus string
+
+const (
+	CustomerStatusNotVisited CustomerStatus = "NOT_VISITED"
+	CustomerStatusVisited    CustomerStatus = "VISITED"
+	CustomerStatusAbsent     CustomerStatus = "ABSENT"
+)
+
+type Customer struct {
+	ID         string
+	Name       string
+	Lat        float64
+	Lng        float64
+	SurveyorID string
+	WorkZoneID string
+	Status     CustomerStatus
+}
+type Customers []Customer
+
+type CustomerFilter struct {
+	// 空の場合は全てのお客さま
+	IDs        []string
+	SurveyorID string
+}
+
+type CustomerUseCase interface {
+	// MoveCustomer はお客さまの位置・作業区を変更し、変更を通知します
+	MoveCustomer(ctx context.Context, m CustomerMoved) error
+}
+
+type CustomerRepository interface {
+	CountCustomersByStatus(ctx context.Context) (map[CustomerStatus]int, error)
+	GetCustomers(ctx context.Context, filter CustomerFilter) (Customers, error)
+	UpdateCustomerLocation(ctx context.Context, m CustomerMoved) error
+	UpdateCustomerStatus(ctx context.Context, id string, status CustomerStatus) error
+}
@@ -0,0 +1,17 @@
+package domain
+
+import "errors"
+
+// リポジトリが返すエラー
+// リポジトリの実装はデータストア固有のエラーをこれらに変換し（fmt.Errorf の %w でラップして返す）、
+// ユースケースは errors.Is で判定して業務エラー・システムエラーに変換します
+var (
+	// 対象のデータが存在しない
+	ErrNotFound = errors.New("not found")
+	// 一意制約に違反した（既に登録されている）
+	ErrDuplicate = errors.New("duplicate")
+	// 他の処理によって更新されている（楽観ロックの失敗）
+	ErrConflict = errors.New("conflict")
+	// データストアに接続できない（一時的な障害）
+	ErrUnavailable = errors.New("unavailable")
+)
@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// 変更通知の種類
+type EventType string
+
+const (
+	// 作業区の担当の調査員が変更された
+	EventZoneAssigned EventType = "zone.assigned"
+	// お客さまの位置または作業区が変更された
+	EventCustomerMoved EventType = "customer.moved"
+	// お客さまへの訪問結果が記録された
+	EventVisitRecorded EventType = "visit.recorded"
+)
+
+// 変更通知
+type Event struct {
+	// 通知の通番（EventPublisher が採番する）
+	ID         uint64
+	Type       EventType
+	OfficeID   string
+	OccurredAt time.Time
+	// 種類ごとの内容（ZoneAssigned, CustomerMoved, VisitRecorded）
+	Data any
+}
+
+// 作業区の担当の変更
+type ZoneAssigned struct {
+	WorkZoneID         string
+	SurveyorID         string
+	PreviousSurveyorID string
+}
+
+// お客さまの位置・作業区の変更
+type CustomerMoved struct {
+	CustomerID string
+	WorkZoneID string
+	Lat        float64
+	Lng        float64
+}
+
+// 訪問結果の記録
+type VisitRecorded struct {
+	CustomerID string
+	SurveyorID string
+	Status     CustomerStatus
+}
+
+// EventPublisher は変更を通知します
+// 更新系のユースケースは、更新後（トランザクションのコミット後）に変更を通知してください
+type EventPublisher interface {
+	Publish(ctx context.Context, e Event)
+}
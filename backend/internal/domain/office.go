@@ -0,0 +1,18 @@
+package domain
+
+import "context"
+
+// 事業所
+type Office struct {
+	ID   string
+	Name string
+}
+type Offices []Office
+
+type OfficeUseCase interface {
+	GetOffices(ctx context.Context) (Offices, error)
+}
+
+type OfficeRepository interface {
+	GetOffices(ctx context.Context) (Offices, error)
+}
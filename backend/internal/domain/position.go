@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// 調査員の位置（端末のGPSの測位結果）
+type Position struct {
+	SurveyorID string
+	OfficeID   string
+	Lat        float64
+	Lng        float64
+	// 測位の誤差（メートル）
+	Accuracy float64
+	// 端末で測位した日時
+	RecordedAt time.Time
+	// サーバーで受信した日時
+	ReceivedAt time.Time
+}
+type Positions []Position
+
+// IsStale は最後の測位から staleAfter 以上経過している（端末の電源断や圏外などで位置が更新されていない）かを返します
+func (p Position) IsStale(now time.Time, staleAfter time.Duration) bool {
+	return now.Sub(p.RecordedAt) >= staleAfter
+}
+
+// 調査員の位置が更新された（Event.Data は Position）
+const EventPositionUpdated EventType = "position.updated"
+
+type PositionUseCase interface {
+	// RecordPosition は測位結果を最新の位置として保存し、購読者に通知します
+	// 前回の保存から間隔が短すぎる場合や、前回より古い測位結果の場合は保存せずに false を返します
+	RecordPosition(ctx context.Context, p Position) (bool, error)
+	GetLatestPositions(ctx context.Context, officeID string) (Positions, error)
+}
+
+type PositionRepository interface {
+	// SaveLatestPosition は調査員の最新の位置を保存します（以前の位置は上書きします）
+	SaveLatestPosition(ctx context.Context, p Position) error
+	// GetLatestPosition は調査員の最新の位置を返します。保存されていない場合は ErrNotFound を返します
+	GetLatestPosition(ctx context.Context, surveyorID string) (Position, error)
+	// GetLatestPositions は事業所の調査員の最新の位置を返します（officeID が空の場合は全ての事業所）
+	GetLatestPositions(ctx context.Context, officeID string) (Positions, error)
+}
@@ -1,5 +1,7 @@
 package domain
 
+import "context"
+
 type Sample struct {
 	ID   string
 	Name string
@@ -7,8 +9,8 @@ type Sample struct {
 type Samples []Sample
 
 type SampleRepository interface {
-	GetSamples() (Samples, error)
+	GetSamples(ctx context.Context) (Samples, error)
 }
 type SamplesUseCase interface {
-	GetSamples() (Samples, error)
+	GetSamples(ctx context.Context) (Samples, error)
 }
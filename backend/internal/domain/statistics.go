@@ -0,0 +1,13 @@
+package domain
+
+import "context"
+
+// 業務状況の集計値
+type Statistics struct {
+	CustomersByStatus   map[CustomerStatus]int
+	UnassignedWorkZones int
+}
+
+type StatisticsUseCase interface {
+	GetStatistics(ctx context.Context) (Statistics, error)
+}
@@ -1,5 +1,7 @@
 package domain
 
+import "context"
+
 type Surveyor struct {
 	ID         string
 	Name       string
@@ -14,9 +16,13 @@ type SurveyorFilter struct {
 }
 
 type SurveyUseCase interface {
-	GetSurveyors(filter SurveyorFilter) (Surveyors, error)
+	GetSurveyors(ctx context.Context, filter SurveyorFilter) (Surveyors, error)
+	// GetSurveyorsVersion は調査員のデータのバージョンを返します（条件付きリクエストでデータを取得せずに変更の有無を判定するため）
+	GetSurveyorsVersion(ctx context.Context) (string, error)
 }
 
 type SurveyRepository interface {
-	GetSurveyors(filter SurveyorFilter) (Surveyors, error)
+	GetSurveyors(ctx context.Context, filter SurveyorFilter) (Surveyors, error)
+	// GetSurveyorsVersion は調査員のデータのバージョン（いずれかの調査員が更新されるたびに変わる値）を返します
+	GetSurveyorsVersion(ctx context.Context) (string, error)
 }
@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// 調査員の軌跡を構成する測位結果
+type TrackPoint struct {
+	Lat float64
+	Lng float64
+	// 測位の誤差（メートル）
+	Accuracy float64
+	// 端末で測位した日時
+	RecordedAt time.Time
+}
+type TrackPoints []TrackPoint
+
+// 調査員が一定の範囲に一定時間以上とどまっていた滞在（訪問先での作業など）
+type Stop struct {
+	// 滞在中の位置の重心
+	Lat   float64
+	Lng   float64
+	Start time.Time
+	End   time.Time
+	// 滞在した位置の近くの担当のお客さま（該当なしの場合は空）
+	CustomerID string
+}
+type Stops []Stop
+
+// 調査員の1日の軌跡
+type Track struct {
+	SurveyorID string
+	// 軌跡の日付（設定のタイムゾーンでの日付）
+	Date time.Time
+	// 簡略化した軌跡（地図に表示する点）
+	Points TrackPoints
+	// 簡略化する前の測位結果の件数
+	RawPointCount int
+	// 移動距離（メートル、簡略化する前の測位結果から計算）
+	Distance float64
+	// 最初の測位から最後の測位までの時間
+	Duration time.Duration
+	// 滞在を除いた移動の時間
+	MovingDuration time.Duration
+	Stops          Stops
+}
+
+type TrackUseCase interface {
+	// GetTrack は調査員の指定した日付の軌跡を tolerance（メートル）で簡略化して返します
+	// 測位結果がない場合は点のない軌跡を返します
+	GetTrack(ctx context.Context, surveyorID string, date time.Time, tolerance float64) (Track, error)
+}
+
+type TrackRepository interface {
+	// AppendTrackPoint は調査員の指定した日付の軌跡に測位結果を追加します
+	AppendTrackPoint(ctx context.Context, surveyorID string, date time.Time, p TrackPoint) error
+	// GetTrackPoints は調査員の指定した日付の測位結果を測位の日時の順に返します
+	GetTrackPoints(ctx context.Context, surveyorID string, date time.Time) (TrackPoints, error)
+}
@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"context"
+	"sync"
+)
+
+// TxManager は複数のリポジトリにまたがる処理を1つのトランザクションで実行します
+//
+// トランザクションはcontextで引き継がれ、fn に渡されたcontextを使用したリポジトリの処理は同じトランザクションに参加します
+// 実装はトランザクションを開始する際に WithRollbackHooks でcontextにRollbackHooksを設定し、ロールバックした場合は登録された処理を実行します
+type TxManager interface {
+	// WithinTx は fn をトランザクション内で実行し、fn がエラーを返した場合（panicを含む）はロールバック、それ以外はコミットします
+	// ctx が既にトランザクション内の場合はセーブポイントを作成し、fn が失敗した場合はセーブポイントまでロールバックします
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type rollbackHooksKey struct{}
+
+// RollbackHooks はトランザクションがロールバックされた場合に実行する処理です
+// メモリ上のデータを扱うリポジトリは、TxManagerの実装によらず OnRollback で変更を取り消す処理を登録します
+type RollbackHooks struct {
+	mu    sync.Mutex
+	undos []func()
+}
+
+// WithRollbackHooks はRollbackHooksを保持したcontextを返します
+func WithRollbackHooks(ctx context.Context, h *RollbackHooks) context.Context {
+	return context.WithValue(ctx, rollbackHooksKey{}, h)
+}
+
+// Savepoint は現時点までに登録された処理の数を返します
+// セーブポイントまでロールバックする場合は、この値を RollbackTo に渡します
+func (h *RollbackHooks) Savepoint() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.undos)
+}
+
+// RollbackTo は savepoint 以降に登録された処理を登録の逆順に実行します
+func (h *RollbackHooks) RollbackTo(savepoint int) {
+	h.mu.Lock()
+	undos := h.undos[savepoint:]
+	h.undos = h.undos[:savepoint]
+	h.mu.Unlock()
+
+	for i := len(undos) - 1; i >= 0; i-- {
+		undos[i]()
+	}
+}
+
+// InTx は ctx がトランザクション内かどうかを返します
+// トランザクション内の参照は更新と整合させる必要があるため、キャッシュを使用しない場合などに使用します
+func InTx(ctx context.Context) bool {
+	_, ok := ctx.Value(rollbackHooksKey{}).(*RollbackHooks)
+	return ok
+}
+
+// OnRollback はトランザクションがロールバックされた場合に実行する処理を登録します
+// ctx がトランザクション内でない場合は変更がそのまま確定するため、何もしません
+func OnRollback(ctx context.Context, undo func()) {
+	h, ok := ctx.Value(rollbackHooksKey{}).(*RollbackHooks)
+	if !ok {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.undos = append(h.undos, undo)
+}
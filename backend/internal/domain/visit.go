@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// お客さまへの訪問結果の記録
+type Visit struct {
+	ID         string
+	CustomerID string
+	SurveyorID string
+	OfficeID   string
+	Status     CustomerStatus
+	// 訪問結果を記録した日時
+	RecordedAt time.Time
+	// 記録した時点の端末の測位結果（測位できなかった場合は nil）
+	Fix *TrackPoint
+}
+type Visits []Visit
+
+// 訪問結果を記録した位置の分類
+type VisitLocation string
+
+const (
+	// お客さまの位置で記録された
+	VisitLocationOnSite VisitLocation = "ON_SITE"
+	// お客さまの位置の近くで記録された
+	VisitLocationNear VisitLocation = "NEAR"
+	// お客さまの位置から離れた場所で記録された
+	VisitLocationFar VisitLocation = "FAR"
+	// 測位結果も前後の軌跡もないため確認できない
+	VisitLocationNoFix VisitLocation = "NO_FIX"
+)
+
+// Geofence は訪問結果を記録した位置を分類する条件です
+type Geofence struct {
+	// お客さまの位置からこの距離（メートル）以内を現地とみなす
+	OnSiteRadius float64
+	// お客さまの位置からこの距離（メートル）以内を近隣とみなす（超える場合は遠方）
+	NearRadius float64
+	// 訪問結果の記録日時の前後で軌跡と比較する時間
+	TrackWindow time.Duration
+}
+
+// Classify はお客さまの位置からの距離（メートル）を分類します
+func (g Geofence) Classify(distance float64) VisitLocation {
+	switch {
+	case distance <= g.OnSiteRadius:
+		return VisitLocationOnSite
+	case distance <= g.NearRadius:
+		return VisitLocationNear
+	default:
+		return VisitLocationFar
+	}
+}
+
+// 訪問結果を記録した位置の確認結果
+type VisitCheck struct {
+	Visit
+	Location VisitLocation
+	// 記録時の測位結果とお客さまの位置の距離（メートル、測位結果がない場合は nil）
+	FixDistance *float64
+	// 記録日時の前後の軌跡のうちお客さまの位置に最も近い点の距離（メートル、軌跡がない場合は nil）
+	TrackDistance *float64
+}
+type VisitChecks []VisitCheck
+
+type VisitFilter struct {
+	OfficeID   string
+	SurveyorID string
+	// 記録日時の範囲（From 以上 To 未満）
+	From time.Time
+	To   time.Time
+}
+
+type VisitAnomalyFilter struct {
+	OfficeID   string
+	SurveyorID string
+	// 訪問結果を記録した日付（設定のタイムゾーンでの日付、日付のみを使用します）
+	Date time.Time
+	// 対象とする分類（空の場合は現地以外の全て）
+	Locations []VisitLocation
+	Geofence  Geofence
+}
+
+type VisitUseCase interface {
+	// GetVisitAnomalies は訪問結果を記録した位置を確認し、filter.Locations のいずれかに分類された訪問を記録日時の順に返します
+	GetVisitAnomalies(ctx context.Context, filter VisitAnomalyFilter) (VisitChecks, error)
+	// RecordVisit は訪問結果を記録し、お客さまの訪問状況を更新して変更を通知します
+	RecordVisit(ctx context.Context, v Visit) error
+}
+
+type VisitRepository interface {
+	// GetVisits は訪問結果を記録日時の順に返します
+	GetVisits(ctx context.Context, filter VisitFilter) (Visits, error)
+	SaveVisit(ctx context.Context, v Visit) error
+}
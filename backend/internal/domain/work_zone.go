@@ -0,0 +1,31 @@
+package domain
+
+import "context"
+
+// 作業区
+type WorkZone struct {
+	ID         string
+	Name       string
+	OfficeID   string
+	SurveyorID string
+}
+type WorkZones []WorkZone
+
+type WorkZoneFilter struct {
+	// 空の場合は全ての事業所
+	OfficeID string
+}
+
+type WorkZoneUseCase interface {
+	// GetWorkZones は作業区と担当の調査員を返します（地図の表示のため）
+	GetWorkZones(ctx context.Context, filter WorkZoneFilter) (WorkZones, error)
+	// AssignSurveyor は作業区の担当の調査員を変更し、変更を通知します（surveyorID が空の場合は担当を外します）
+	AssignSurveyor(ctx context.Context, workZoneID, surveyorID string) (WorkZone, error)
+}
+
+type WorkZoneRepository interface {
+	CountUnassignedWorkZones(ctx context.Context) (int, error)
+	GetWorkZone(ctx context.Context, id string) (WorkZone, error)
+	GetWorkZones(ctx context.Context, filter WorkZoneFilter) (WorkZones, error)
+	UpdateWorkZoneSurveyor(ctx context.Context, id, surveyorID string) error
+}
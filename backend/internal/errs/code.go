@@ -1,26 +1,86 @@
 package errs
 
+import (
+	"cmp"
+	"react-ts/backend/internal/i18n"
+	"slices"
+)
+
 // アプリ固有のエラーコード
 type ErrorCode string
 
 // 属性
 type errorCodeAttribute struct {
-	status  int
-	message string
+	status int
+	// メッセージカタログ（i18n）のキー
+	messageKey string
+	// 同じリクエストを再実行すると成功する可能性があるか
+	retryable bool
 }
 
 const (
-	InvalidRequest ErrorCode = "INVALID_REQUEST"
-	NotFound       ErrorCode = "NOT_FOUND"
-	Exclusion      ErrorCode = "EXCLUSION"
-	Internal       ErrorCode = "INTERNAL"
+	InvalidRequest        ErrorCode = "INVALID_REQUEST"
+	Unauthenticated       ErrorCode = "UNAUTHENTICATED"
+	Forbidden             ErrorCode = "FORBIDDEN"
+	NotFound              ErrorCode = "NOT_FOUND"
+	Exclusion             ErrorCode = "EXCLUSION"
+	Conflict              ErrorCode = "CONFLICT"
+	Duplicate             ErrorCode = "DUPLICATE"
+	PreconditionFailed    ErrorCode = "PRECONDITION_FAILED"
+	PayloadTooLarge       ErrorCode = "PAYLOAD_TOO_LARGE"
+	BusinessRuleViolation ErrorCode = "BUSINESS_RULE_VIOLATION"
+	TooManyRequests       ErrorCode = "TOO_MANY_REQUESTS"
+	ClientClosedRequest   ErrorCode = "CLIENT_CLOSED_REQUEST"
+	Internal              ErrorCode = "INTERNAL"
+	ServiceUnavailable    ErrorCode = "SERVICE_UNAVAILABLE"
+	Timeout               ErrorCode = "TIMEOUT"
 )
 
 var attributes = map[ErrorCode]errorCodeAttribute{
-	InvalidRequest: {status: 400, message: "リクエストの形式が不正です"},
-	NotFound:       {status: 404, message: "データがありません"},
-	Exclusion:      {status: 409, message: "すでに削除されています"},
-	Internal:       {status: 500, message: "想定外のエラーが発生しました"},
+	InvalidRequest:        {status: 400, messageKey: "error.invalidRequest"},
+	Unauthenticated:       {status: 401, messageKey: "error.unauthenticated"},
+	Forbidden:             {status: 403, messageKey: "error.forbidden"},
+	NotFound:              {status: 404, messageKey: "error.notFound"},
+	Exclusion:             {status: 409, messageKey: "error.exclusion"},
+	Conflict:              {status: 409, messageKey: "error.conflict"},
+	Duplicate:             {status: 409, messageKey: "error.duplicate"},
+	PreconditionFailed:    {status: 412, messageKey: "error.preconditionFailed"},
+	PayloadTooLarge:       {status: 413, messageKey: "error.payloadTooLarge"},
+	BusinessRuleViolation: {status: 422, messageKey: "error.businessRuleViolation"},
+	TooManyRequests:       {status: 429, messageKey: "error.tooManyRequests", retryable: true},
+	// クライアントが切断した場合（nginxの慣例に合わせて499とする）
+	ClientClosedRequest: {status: 499, messageKey: "error.clientClosedRequest", retryable: true},
+	Internal:            {status: 500, messageKey: "error.internal"},
+	ServiceUnavailable:  {status: 503, messageKey: "error.serviceUnavailable", retryable: true},
+	Timeout:             {status: 504, messageKey: "error.timeout", retryable: true},
+}
+
+// 未定義のエラーコードのメッセージキー
+const unknownMessageKey = "error.unknown"
+
+// CodeInfo はエラーコードの属性です
+type CodeInfo struct {
+	Code       ErrorCode
+	Status     int
+	MessageKey string
+	Retryable  bool
+}
+
+// Codes は定義されている全てのエラーコードの属性をコード順に返します
+func Codes() []CodeInfo {
+	codes := make([]CodeInfo, 0, len(attributes))
+	for code, attr := range attributes {
+		codes = append(codes, CodeInfo{
+			Code:       code,
+			Status:     attr.status,
+			MessageKey: attr.messageKey,
+			Retryable:  attr.retryable,
+		})
+	}
+	slices.SortFunc(codes, func(a, b CodeInfo) int {
+		return cmp.Or(cmp.Compare(a.Status, b.Status), cmp.Compare(a.Code, b.Code))
+	})
+	return codes
 }
 
 func (e ErrorCode) GetStatus() int {
@@ -30,9 +90,25 @@ func (e ErrorCode) GetStatus() int {
 	return 500
 }
 
+// GetMessage はデフォルトの言語のメッセージを返します
 func (e ErrorCode) GetMessage() string {
+	return e.Message(i18n.Default)
+}
+
+// Message は指定した言語のメッセージを返します。翻訳がない場合はデフォルトの言語のメッセージを返します
+func (e ErrorCode) Message(lang i18n.Lang) string {
+	return i18n.Message(lang, e.MessageKey())
+}
+
+// MessageKey はメッセージカタログのキーを返します
+func (e ErrorCode) MessageKey() string {
 	if attr, ok := attributes[e]; ok {
-		return attr.message
+		return attr.messageKey
 	}
-	return "不明なエラーです"
+	return unknownMessageKey
+}
+
+// IsRetryable は同じリクエストを再実行すると成功する可能性があるかを返します
+func (e ErrorCode) IsRetryable() bool {
+	return attributes[e].retryable
 }
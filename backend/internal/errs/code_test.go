@@ -0,0 +1,31 @@
+package errs
+
+import (
+	"react-ts/backend/internal/i18n"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// 全てのエラーコードに全ての言語のメッセージが定義されていること
+func Test_ErrorCode_Messages(t *testing.T) {
+	for _, c := range Codes() {
+		assert.True(t, i18n.HasMessage(c.MessageKey), "%s (%s)", c.Code, c.MessageKey)
+	}
+	assert.True(t, i18n.HasMessage(unknownMessageKey))
+}
+
+func Test_ErrorCode_Message(t *testing.T) {
+	assert.Equal(t, "データがありません", NotFound.Message(i18n.Japanese))
+	assert.Equal(t, "The requested data was not found", NotFound.Message(i18n.English))
+	assert.Equal(t, "Unknown error", ErrorCode("UNKNOWN").Message(i18n.English))
+}
+
+func Test_Codes(t *testing.T) {
+	codes := Codes()
+	assert.Len(t, codes, len(attributes))
+	assert.Equal(t, InvalidRequest, codes[0].Code)
+	assert.Equal(t, Timeout, codes[len(codes)-1].Code)
+	assert.True(t, TooManyRequests.IsRetryable())
+	assert.False(t, NotFound.IsRetryable())
+}
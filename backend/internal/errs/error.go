@@ -1,11 +1,31 @@
 package errs
 
-import "fmt"
+import (
+	"fmt"
+	"runtime"
+	"strings"
+)
 
 // 業務エラーを表現するエラー
 type BusinessError struct {
-	code    ErrorCode
-	details []string
+	code       ErrorCode
+	details    []string
+	violations []Violation
+	// 業務エラーの原因となったエラー（リポジトリのエラーなど）
+	cause error
+}
+
+// 入力項目ごとの検証エラー
+type Violation struct {
+	// 項目名（クエリパラメータ名やJSONの項目名）
+	Field string
+	// 違反した検証ルール（required, max など）
+	Rule string
+	// 検証ルールのパラメータ（max=3 の 3 など）
+	Param string
+	// 入力された値
+	RejectedValue any
+	Message       string
 }
 
 func NewBusinessError(errCode ErrorCode, details ...string) *BusinessError {
@@ -15,10 +35,37 @@ func NewBusinessError(errCode ErrorCode, details ...string) *BusinessError {
 	}
 }
 
+// WithViolations は入力項目ごとの検証エラーを設定します
+func (e *BusinessError) WithViolations(violations ...Violation) *BusinessError {
+	e.violations = violations
+	return e
+}
+
+// WithCause は業務エラーの原因となったエラーを設定します
+// 原因はログやトレースにのみ出力され、クライアントには返しません
+func (e *BusinessError) WithCause(cause error) *BusinessError {
+	e.cause = cause
+	return e
+}
+
 func (e *BusinessError) Error() string {
+	if e.cause != nil {
+		return fmt.Sprintf("[%s] %s details: %v cause: %v", e.code, e.code.GetMessage(), e.details, e.cause)
+	}
 	return fmt.Sprintf("[%s] %s details: %v", e.code, e.code.GetMessage(), e.details)
 }
 
+func (e *BusinessError) Unwrap() error {
+	return e.cause
+}
+
+// Is はエラーコードが同じ業務エラーであるかを返します（詳細や原因は比較しません）
+// errors.Is(err, errs.NewBusinessError(errs.NotFound)) のように、エラーの分類を判定できます
+func (e *BusinessError) Is(target error) bool {
+	t, ok := target.(*BusinessError)
+	return ok && t.code == e.code
+}
+
 func (e *BusinessError) GetCode() ErrorCode {
 	return e.code
 }
@@ -27,26 +74,75 @@ func (e *BusinessError) GetDetails() []string {
 	return e.details
 }
 
+func (e *BusinessError) GetViolations() []Violation {
+	return e.violations
+}
+
 // 想定外のエラーを表現するエラー
 type SystemError struct {
+	code    ErrorCode
 	message string
 	cause   error
+	// エラーを生成した時点のスタックトレース
+	stack []uintptr
 }
 
+// スタックトレースとして保持する呼び出し元の最大数
+const maxStackDepth = 32
+
+// NewSystemError はシステムエラーを生成します。エラーコードは errs.Internal となります
+// message には処理の名前（SurveyUseCase.GetSurveyors など）を設定し、どこで発生したかを特定できるようにしてください
 func NewSystemError(message string, cause error) *SystemError {
+	pcs := make([]uintptr, maxStackDepth)
+	n := runtime.Callers(2, pcs)
 	return &SystemError{
+		code:    Internal,
 		message: message,
 		cause:   cause,
+		stack:   pcs[:n],
 	}
 }
 
+// WithCode はエラーコードを設定します（一時的な障害を errs.ServiceUnavailable とする場合など）
+func (e *SystemError) WithCode(code ErrorCode) *SystemError {
+	e.code = code
+	return e
+}
+
 func (e *SystemError) Error() string {
 	if e.cause != nil {
-		return fmt.Sprintf("[%s] %s cause: %v", Internal, e.message, e.cause)
+		return fmt.Sprintf("[%s] %s: %v", e.code, e.message, e.cause)
 	}
-	return fmt.Sprintf("[%s] %s", Internal, e.message)
+	return fmt.Sprintf("[%s] %s", e.code, e.message)
 }
 
 func (e *SystemError) Unwrap() error {
 	return e.cause
 }
+
+// Is はエラーコードが同じシステムエラーであるかを返します（メッセージや原因は比較しません）
+func (e *SystemError) Is(target error) bool {
+	t, ok := target.(*SystemError)
+	return ok && t.code == e.code
+}
+
+func (e *SystemError) GetCode() ErrorCode {
+	return e.code
+}
+
+// Stack はエラーを生成した時点のスタックトレースを返します
+func (e *SystemError) Stack() string {
+	if len(e.stack) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	frames := runtime.CallersFrames(e.stack)
+	for {
+		f, more := frames.Next()
+		fmt.Fprintf(&b, "%s\n\t%s:%d\n", f.Function, f.File, f.Line)
+		if !more {
+			break
+		}
+	}
+	return b.String()
+}
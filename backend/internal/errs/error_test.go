@@ -0,0 +1,38 @@
+package errs
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_BusinessError_Is(t *testing.T) {
+	cause := errors.New("not found")
+	err := fmt.Errorf("op: %w", NewBusinessError(NotFound, "xxxx").WithCause(cause))
+
+	assert.ErrorIs(t, err, NewBusinessError(NotFound))
+	assert.NotErrorIs(t, err, NewBusinessError(Conflict))
+	assert.NotErrorIs(t, err, NewSystemError("", nil))
+	// 原因のエラーも判定できる
+	assert.ErrorIs(t, err, cause)
+
+	var be *BusinessError
+	if assert.ErrorAs(t, err, &be) {
+		assert.Equal(t, NotFound, be.GetCode())
+		assert.Equal(t, []string{"xxxx"}, be.GetDetails())
+	}
+}
+
+func Test_SystemError(t *testing.T) {
+	cause := errors.New("connection refused")
+	err := NewSystemError("SurveyUseCase.GetSurveyors", cause).WithCode(ServiceUnavailable)
+
+	assert.Equal(t, "[SERVICE_UNAVAILABLE] SurveyUseCase.GetSurveyors: connection refused", err.Error())
+	assert.ErrorIs(t, err, cause)
+	assert.ErrorIs(t, err, NewSystemError("", nil).WithCode(ServiceUnavailable))
+	assert.NotErrorIs(t, err, NewSystemError("", nil))
+	// スタックトレースにはエラーを生成した関数が含まれる
+	assert.Contains(t, err.Stack(), "errs.Test_SystemError")
+}
@@ -0,0 +1,190 @@
+// Package event は変更通知を購読者（Server-Sent Eventsの接続など）に配信するブローカーです。
+package event
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"react-ts/backend/internal/domain"
+	"react-ts/backend/internal/metrics"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// ErrClosed は停止したブローカーを購読しようとした場合のエラーです
+var ErrClosed = errors.New("event broker closed")
+
+// Broker は通知に通番を採番して購読者に配信し、再接続時に再送するため直近の通知を保持します
+// 配信はプロセス内に限られるため、複数のインスタンスで動作させる場合は外部のメッセージブローカーが必要です
+type Broker struct {
+	bufferSize  int
+	now         func() time.Time
+	subscribers prometheus.Gauge
+
+	mu sync.Mutex
+	// 直近の通知（リングバッファ）
+	replay []domain.Event
+	head   int
+	count  int
+	lastID uint64
+	subs   map[*Subscription]struct{}
+	closed bool
+	done   chan struct{}
+}
+
+var _ domain.EventPublisher = (*Broker)(nil)
+
+// NewBroker はブローカーを生成します
+// name はメトリクスで購読者数を区別するための通知の種類（events, positions など）です
+// replaySize は再送のため保持する通知の件数、bufferSize は購読者ごとに送信待ちにできる通知の件数です
+func NewBroker(name string, replaySize, bufferSize int) *Broker {
+	return &Broker{
+		bufferSize:  bufferSize,
+		now:         time.Now,
+		subscribers: metrics.EventSubscribers.WithLabelValues(name),
+		replay:      make([]domain.Event, replaySize),
+		subs:        map[*Subscription]struct{}{},
+		done:        make(chan struct{}),
+	}
+}
+
+// Subscription は通知の購読です
+type Subscription struct {
+	// 購読の開始時に再送する通知
+	Replay []domain.Event
+	// 再送すべき通知が既に破棄されている（またはプロセスの再起動などで不明な）場合は true
+	// 購読者はデータを取得し直す必要があります
+	Missed bool
+
+	ch     chan domain.Event
+	filter func(domain.Event) bool
+	b      *Broker
+}
+
+// Events は通知を受け取るチャネルを返します
+// 送信待ちの通知が上限を超えた場合やブローカーが停止した場合は閉じられます（再接続して再送を受けてください）
+func (s *Subscription) Events() <-chan domain.Event {
+	return s.ch
+}
+
+// Close は購読を終了します
+func (s *Subscription) Close() {
+	s.b.mu.Lock()
+	defer s.b.mu.Unlock()
+	s.b.unsubscribe(s)
+}
+
+// Publish は通知に通番と発生日時（未設定の場合）を設定し、条件に一致する購読者に配信します
+// 送信待ちの通知が上限を超えた購読者は、他の購読者や通知元を待たせないよう購読を終了させます
+func (b *Broker) Publish(ctx context.Context, e domain.Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return
+	}
+	b.lastID++
+	e.ID = b.lastID
+	if e.OccurredAt.IsZero() {
+		e.OccurredAt = b.now()
+	}
+	if len(b.replay) > 0 {
+		b.replay[(b.head+b.count)%len(b.replay)] = e
+		if b.count < len(b.replay) {
+			b.count++
+		} else {
+			b.head = (b.head + 1) % len(b.replay)
+		}
+	}
+
+	for s := range b.subs {
+		if !s.filter(e) {
+			continue
+		}
+		select {
+		case s.ch <- e:
+		default:
+			slog.WarnContext(ctx, "event subscriber is too slow, closing subscription", "eventId", e.ID)
+			b.unsubscribe(s)
+		}
+	}
+}
+
+// Subscribe は filter に一致する通知の購読を開始します
+// lastID が0より大きい場合は、保持している通知のうち lastID より後のものを Subscription.Replay に設定します
+func (b *Broker) Subscribe(lastID uint64, filter func(domain.Event) bool) (*Subscription, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return nil, ErrClosed
+	}
+	s := &Subscription{
+		ch:     make(chan domain.Event, b.bufferSize),
+		filter: filter,
+		b:      b,
+	}
+	if lastID > 0 {
+		// 保持している最も古い通知の通番
+		oldest := b.lastID - uint64(b.count) + 1
+		switch {
+		case lastID > b.lastID, lastID+1 < oldest:
+			s.Missed = true
+		default:
+			for i := range b.count {
+				e := b.replay[(b.head+i)%len(b.replay)]
+				if e.ID > lastID && filter(e) {
+					s.Replay = append(s.Replay, e)
+				}
+			}
+		}
+	}
+	b.subs[s] = struct{}{}
+	b.subscribers.Inc()
+	return s, nil
+}
+
+// Close は全ての購読を終了し、以降の通知・購読を受け付けないようにします
+// ストリーミング中のリクエストを終了させるため、サーバーの停止を開始した時点で呼び出してください
+func (b *Broker) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return
+	}
+	b.closed = true
+	close(b.done)
+	for s := range b.subs {
+		b.unsubscribe(s)
+	}
+}
+
+// Ping はブローカーが配信できる状態かを確認し、停止済みの場合は ErrClosed を返します（readiness probe用）
+// 停止処理の間は新たな接続を振り分けられないよう、readiness probe を失敗させます
+func (b *Broker) Ping(ctx context.Context) error {
+	select {
+	case <-b.done:
+		return ErrClosed
+	default:
+		return ctx.Err()
+	}
+}
+
+// Done はブローカーが停止すると閉じられるチャネルを返します
+// 購読以外の処理（WebSocketの受信など）もサーバーの停止に合わせて終了させる場合に使用します
+func (b *Broker) Done() <-chan struct{} {
+	return b.done
+}
+
+// unsubscribe は購読を終了します（b.mu をロックして呼び出すこと）
+func (b *Broker) unsubscribe(s *Subscription) {
+	if _, ok := b.subs[s]; !ok {
+		return
+	}
+	delete(b.subs, s)
+	close(s.ch)
+	b.subscribers.Dec()
+}
@@ -0,0 +1,150 @@
+package event
+
+import (
+	"context"
+	"react-ts/backend/internal/domain"
+	"react-ts/backend/internal/metrics"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func office(id string) func(domain.Event) bool {
+	return func(e domain.Event) bool { return e.OfficeID == id }
+}
+
+// ids はチャネルに届いている通知の通番を返します
+func ids(ch <-chan domain.Event) []uint64 {
+	var ret []uint64
+	for {
+		select {
+		case e, ok := <-ch:
+			if !ok {
+				return ret
+			}
+			ret = append(ret, e.ID)
+		default:
+			return ret
+		}
+	}
+}
+
+func Test_Broker_Publish(t *testing.T) {
+	ctx := context.Background()
+	b := NewBroker("test", 10, 10)
+	xx, _ := b.Subscribe(0, office("XX"))
+	yy, _ := b.Subscribe(0, office("YY"))
+
+	b.Publish(ctx, domain.Event{Type: domain.EventZoneAssigned, OfficeID: "XX"})
+	b.Publish(ctx, domain.Event{Type: domain.EventVisitRecorded, OfficeID: "YY"})
+	b.Publish(ctx, domain.Event{Type: domain.EventCustomerMoved, OfficeID: "XX"})
+
+	// 事業所で絞り込まれ、通番が採番されていること
+	assert.Equal(t, []uint64{1, 3}, ids(xx.Events()))
+	assert.Equal(t, []uint64{2}, ids(yy.Events()))
+
+	xx.Close()
+	b.Publish(ctx, domain.Event{OfficeID: "XX"})
+	_, ok := <-xx.Events()
+	assert.False(t, ok)
+}
+
+func Test_Broker_Replay(t *testing.T) {
+	ctx := context.Background()
+	b := NewBroker("test", 3, 10)
+	for _, o := range []string{"XX", "YY", "XX", "XX", "XX"} {
+		b.Publish(ctx, domain.Event{OfficeID: o})
+	}
+
+	tests := []struct {
+		name     string
+		lastID   uint64
+		expected []uint64
+		missed   bool
+	}{
+		{name: "New", lastID: 0},
+		{name: "Resume", lastID: 3, expected: []uint64{4, 5}},
+		{name: "UpToDate", lastID: 5},
+		// 通番2までは破棄されている
+		{name: "Evicted", lastID: 1, missed: true},
+		// 再起動前の通番
+		{name: "Unknown", lastID: 6, missed: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := b.Subscribe(tt.lastID, office("XX"))
+			assert.NoError(t, err)
+			defer s.Close()
+
+			var got []uint64
+			for _, e := range s.Replay {
+				got = append(got, e.ID)
+			}
+			assert.Equal(t, tt.expected, got)
+			assert.Equal(t, tt.missed, s.Missed)
+		})
+	}
+}
+
+// 送信待ちが上限を超えた購読者は購読を終了させられること
+func Test_Broker_SlowSubscriber(t *testing.T) {
+	ctx := context.Background()
+	b := NewBroker("test", 10, 2)
+	slow, _ := b.Subscribe(0, office("XX"))
+	fast, _ := b.Subscribe(0, office("XX"))
+
+	for range 2 {
+		b.Publish(ctx, domain.Event{OfficeID: "XX"})
+	}
+	assert.Len(t, ids(fast.Events()), 2)
+	b.Publish(ctx, domain.Event{OfficeID: "XX"})
+
+	assert.Equal(t, []uint64{1, 2}, ids(slow.Events()))
+	_, ok := <-slow.Events()
+	assert.False(t, ok)
+	assert.Equal(t, []uint64{3}, ids(fast.Events()))
+}
+
+func Test_Broker_Close(t *testing.T) {
+	b := NewBroker("test", 10, 10)
+	s, _ := b.Subscribe(0, office("XX"))
+	assert.NoError(t, b.Ping(context.Background()))
+
+	b.Close()
+	assert.ErrorIs(t, b.Ping(context.Background()), ErrClosed)
+	_, ok := <-s.Events()
+	assert.False(t, ok)
+	<-b.Done()
+	// 停止済みのブローカーを停止しても問題ないこと
+	b.Close()
+	// 終了済みの購読を終了しても問題ないこと
+	s.Close()
+
+	_, err := b.Subscribe(0, office("XX"))
+	assert.ErrorIs(t, err, ErrClosed)
+}
+
+func Test_Broker_SubscribersMetric(t *testing.T) {
+	events := NewBroker("test-events", 10, 10)
+	positions := NewBroker("test-positions", 0, 10)
+	gauge := func(stream string) float64 {
+		return testutil.ToFloat64(metrics.EventSubscribers.WithLabelValues(stream))
+	}
+
+	s1, _ := events.Subscribe(0, office("XX"))
+	events.Subscribe(0, office("XX"))
+	s3, _ := positions.Subscribe(0, office("XX"))
+	// 通知の種類ごとに購読者数を計数する
+	assert.Equal(t, 2.0, gauge("test-events"))
+	assert.Equal(t, 1.0, gauge("test-positions"))
+
+	s1.Close()
+	s3.Close()
+	assert.Equal(t, 1.0, gauge("test-events"))
+	assert.Equal(t, 0.0, gauge("test-positions"))
+
+	// 停止時に残っていた購読も計数から除く
+	events.Close()
+	assert.Equal(t, 0.0, gauge("test-events"))
+}
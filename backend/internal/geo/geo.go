@@ -0,0 +1,88 @@
+// Package geo は緯度経度の計算（距離・線の簡略化・滞在の検出）を行います。
+//
+// 距離はメートル単位で、地球を球とみなして計算します（調査員の移動の範囲では誤差は無視できます）。
+package geo
+
+import "math"
+
+// 地球の平均半径（メートル）
+const earthRadius = 6371008.8
+
+// Point は緯度経度です
+type Point struct {
+	Lat float64
+	Lng float64
+}
+
+// Distance は2点間の大円距離（メートル）を返します
+func Distance(a, b Point) float64 {
+	lat1, lat2 := radians(a.Lat), radians(b.Lat)
+	dLat := lat2 - lat1
+	dLng := radians(b.Lng - a.Lng)
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadius * math.Asin(math.Sqrt(min(h, 1)))
+}
+
+// Simplify はDouglas–Peuckerのアルゴリズムで線を簡略化し、残す点の添字を昇順で返します
+// tolerance は簡略化した線からの許容する距離（メートル）です。始点と終点は常に残します
+func Simplify(points []Point, tolerance float64) []int {
+	if len(points) <= 2 {
+		idx := make([]int, len(points))
+		for i := range idx {
+			idx[i] = i
+		}
+		return idx
+	}
+
+	keep := make([]bool, len(points))
+	keep[0], keep[len(points)-1] = true, true
+	// 再帰の代わりに区間のスタックを使用する（点の数が多い場合にスタックが深くならないよう）
+	stack := [][2]int{{0, len(points) - 1}}
+	for len(stack) > 0 {
+		first, last := stack[len(stack)-1][0], stack[len(stack)-1][1]
+		stack = stack[:len(stack)-1]
+
+		maxDist, index := 0.0, -1
+		for i := first + 1; i < last; i++ {
+			if d := segmentDistance(points[i], points[first], points[last]); d > maxDist {
+				maxDist, index = d, i
+			}
+		}
+		if index >= 0 && maxDist > tolerance {
+			keep[index] = true
+			stack = append(stack, [2]int{first, index}, [2]int{index, last})
+		}
+	}
+
+	var idx []int
+	for i, k := range keep {
+		if k {
+			idx = append(idx, i)
+		}
+	}
+	return idx
+}
+
+// segmentDistance は点 p から線分 ab までの距離（メートル）を返します
+// a を原点とした平面（正距円筒図法）に投影して計算します
+func segmentDistance(p, a, b Point) float64 {
+	px, py := project(p, a)
+	bx, by := project(b, a)
+	l2 := bx*bx + by*by
+	if l2 == 0 {
+		return math.Hypot(px, py)
+	}
+	t := max(0, min(1, (px*bx+py*by)/l2))
+	return math.Hypot(px-t*bx, py-t*by)
+}
+
+// project は origin を原点とした平面上の座標（メートル）を返します
+func project(p, origin Point) (x, y float64) {
+	x = radians(p.Lng-origin.Lng) * math.Cos(radians((p.Lat+origin.Lat)/2)) * earthRadius
+	y = radians(p.Lat-origin.Lat) * earthRadius
+	return x, y
+}
+
+func radians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
@@ -0,0 +1,100 @@
+package geo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Distance(t *testing.T) {
+	tokyo := Point{Lat: 35.681236, Lng: 139.767125}
+	shinjuku := Point{Lat: 35.689487, Lng: 139.691706}
+
+	assert.InDelta(t, 6880, Distance(tokyo, shinjuku), 50)
+	assert.InDelta(t, 6880, Distance(shinjuku, tokyo), 50)
+	assert.Zero(t, Distance(tokyo, tokyo))
+	// 緯度1度はおよそ111km
+	assert.InDelta(t, 111195, Distance(Point{Lat: 35, Lng: 139}, Point{Lat: 36, Lng: 139}), 10)
+}
+
+func Test_Simplify(t *testing.T) {
+	// 東に約10mずつ進む線（北に数十cmのぶれを含む）と、途中で北に約100m曲がる線
+	origin := Point{Lat: 35.68, Lng: 139.76}
+	offset := func(north, east float64) Point {
+		return Point{Lat: origin.Lat + north/111195, Lng: origin.Lng + east/(111195*0.8124)}
+	}
+
+	tests := []struct {
+		name      string
+		points    []Point
+		tolerance float64
+		expected  []int
+	}{
+		{name: "Empty", points: nil, tolerance: 5, expected: []int{}},
+		{name: "TwoPoints", points: []Point{offset(0, 0), offset(0, 10)}, tolerance: 5, expected: []int{0, 1}},
+		{
+			name:      "Straight",
+			points:    []Point{offset(0, 0), offset(0.3, 10), offset(-0.2, 20), offset(0.1, 30), offset(0, 40)},
+			tolerance: 5,
+			expected:  []int{0, 4},
+		},
+		{
+			name:      "Corner",
+			points:    []Point{offset(0, 0), offset(0, 50), offset(0, 100), offset(50, 100), offset(100, 100)},
+			tolerance: 5,
+			expected:  []int{0, 2, 4},
+		},
+		{
+			name:      "ZeroTolerance",
+			points:    []Point{offset(0, 0), offset(0.3, 10), offset(0, 20)},
+			tolerance: 0,
+			expected:  []int{0, 1, 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			idx := Simplify(tt.points, tt.tolerance)
+			if len(tt.expected) == 0 {
+				assert.Empty(t, idx)
+				return
+			}
+			assert.Equal(t, tt.expected, idx)
+		})
+	}
+}
+
+func Test_DetectStops(t *testing.T) {
+	start := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	origin := Point{Lat: 35.68, Lng: 139.76}
+	at := func(minute int, east float64) TimedPoint {
+		return TimedPoint{
+			Point: Point{Lat: origin.Lat, Lng: origin.Lng + east/(111195*0.8124)},
+			Time:  start.Add(time.Duration(minute) * time.Minute),
+		}
+	}
+
+	points := []TimedPoint{
+		// 移動
+		at(0, 0), at(1, 200), at(2, 400),
+		// 5分間の滞在（数mのぶれを含む）
+		at(3, 600), at(4, 605), at(5, 598), at(6, 603), at(8, 601),
+		// 移動
+		at(9, 800), at(10, 1000),
+		// 1分だけの停止は滞在とみなさない
+		at(11, 1000), at(12, 1200),
+	}
+
+	stops := DetectStops(points, 30, 3*time.Minute)
+	if assert.Len(t, stops, 1) {
+		s := stops[0]
+		assert.Equal(t, 3, s.First)
+		assert.Equal(t, 7, s.Last)
+		assert.Equal(t, at(3, 0).Time, s.Start)
+		assert.Equal(t, at(8, 0).Time, s.End)
+		assert.Equal(t, 5*time.Minute, s.Duration())
+		assert.InDelta(t, 0, Distance(s.Center, at(0, 601.4).Point), 1)
+	}
+
+	assert.Empty(t, DetectStops(nil, 30, 3*time.Minute))
+}
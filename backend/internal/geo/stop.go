@@ -0,0 +1,59 @@
+package geo
+
+import "time"
+
+// TimedPoint は日時付きの緯度経度です
+type TimedPoint struct {
+	Point
+	Time time.Time
+}
+
+// Stop は一定の範囲に一定時間以上とどまっていた滞在です
+type Stop struct {
+	// 滞在中の点の重心
+	Center Point
+	Start  time.Time
+	End    time.Time
+	// 滞在中の点の添字の範囲 [First, Last]
+	First int
+	Last  int
+}
+
+// Duration は滞在時間を返します
+func (s Stop) Duration() time.Duration {
+	return s.End.Sub(s.Start)
+}
+
+// DetectStops は時刻順の点から、重心から radius メートル以内に minDuration 以上とどまっていた滞在を返します
+// 重心から radius を超える点が現れた時点で滞在が終わったとみなします
+func DetectStops(points []TimedPoint, radius float64, minDuration time.Duration) []Stop {
+	var stops []Stop
+	for i := 0; i < len(points); {
+		center := points[i].Point
+		j := i + 1
+		for ; j < len(points); j++ {
+			if Distance(center, points[j].Point) > radius {
+				break
+			}
+			// 重心を更新する（GPSの誤差で最初の点がずれていても滞在を検出できるよう）
+			n := float64(j - i + 1)
+			center.Lat += (points[j].Lat - center.Lat) / n
+			center.Lng += (points[j].Lng - center.Lng) / n
+		}
+
+		last := j - 1
+		if points[last].Time.Sub(points[i].Time) >= minDuration {
+			stops = append(stops, Stop{
+				Center: center,
+				Start:  points[i].Time,
+				End:    points[last].Time,
+				First:  i,
+				Last:   last,
+			})
+			i = j
+			continue
+		}
+		i++
+	}
+	return stops
+}
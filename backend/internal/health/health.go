@@ -0,0 +1,119 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// チェック結果の状態
+type Status string
+
+const (
+	StatusUp   Status = "UP"
+	StatusDown Status = "DOWN"
+)
+
+// CheckFunc は依存先の状態を確認し、利用できない場合はエラーを返す関数です
+type CheckFunc func(ctx context.Context) error
+
+// Result は個々のチェック結果です
+type Result struct {
+	Status   Status `json:"status" example:"UP"`
+	Error    string `json:"error,omitempty" example:"connection refused"`
+	Duration string `json:"duration" example:"1.2ms"`
+}
+
+// Report は全チェックの結果です
+type Report struct {
+	Status Status            `json:"status" example:"UP"`
+	Checks map[string]Result `json:"checks"`
+}
+
+type check struct {
+	name    string
+	timeout time.Duration
+	fn      CheckFunc
+}
+
+// Registry は各サブシステムが登録したヘルスチェックを保持します
+type Registry struct {
+	mu             sync.RWMutex
+	defaultTimeout time.Duration
+	checks         []check
+}
+
+// NewRegistry はタイムアウト未指定のチェックに defaultTimeout を適用するRegistryを生成します
+func NewRegistry(defaultTimeout time.Duration) *Registry {
+	return &Registry{defaultTimeout: defaultTimeout}
+}
+
+// Register はヘルスチェックを登録します。timeout が0の場合はデフォルトのタイムアウトを使用します
+func (r *Registry) Register(name string, timeout time.Duration, fn CheckFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if timeout <= 0 {
+		timeout = r.defaultTimeout
+	}
+	r.checks = append(r.checks, check{name: name, timeout: timeout, fn: fn})
+}
+
+// Check は登録された全てのチェックを並行に実行し、結果を返します
+// いずれかのチェックが失敗した場合、全体の状態は StatusDown になります
+func (r *Registry) Check(ctx context.Context) Report {
+	r.mu.RLock()
+	checks := append([]check(nil), r.checks...)
+	r.mu.RUnlock()
+
+	results := make([]Result, len(checks))
+	var wg sync.WaitGroup
+	for i, c := range checks {
+		wg.Go(func() {
+			results[i] = run(ctx, c)
+		})
+	}
+	wg.Wait()
+
+	rep := Report{Status: StatusUp, Checks: make(map[string]Result, len(checks))}
+	for i, c := range checks {
+		rep.Checks[c.name] = results[i]
+		if results[i].Status != StatusUp {
+			rep.Status = StatusDown
+		}
+	}
+	return rep
+}
+
+// run はタイムアウト付きでチェックを1件実行します
+func run(ctx context.Context, c check) Result {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if p := recover(); p != nil {
+				done <- fmt.Errorf("panic: %v", p)
+			}
+		}()
+		done <- c.fn(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		// contextを無視するチェックでも応答を待たずにタイムアウトとする
+		err = fmt.Errorf("timed out after %s", c.timeout)
+	}
+
+	res := Result{Status: StatusUp, Duration: time.Since(start).String()}
+	if err != nil {
+		res.Status = StatusDown
+		res.Error = err.Error()
+	}
+	return res
+}
@@ -0,0 +1,62 @@
+package i18n
+
+import (
+	"context"
+
+	"golang.org/x/text/language"
+)
+
+// Lang はレスポンスのメッセージに使用する言語です。
+type Lang string
+
+const (
+	Japanese Lang = "ja"
+	English  Lang = "en"
+)
+
+// Default は言語が指定されていない場合に使用する言語です。
+const Default = Japanese
+
+// Supported は対応している言語です。
+var Supported = []Lang{Japanese, English}
+
+var matcher = language.NewMatcher([]language.Tag{language.Japanese, language.English})
+
+// Valid は対応している言語かどうかを返します。
+func Valid(lang string) bool {
+	for _, l := range Supported {
+		if string(l) == lang {
+			return true
+		}
+	}
+	return false
+}
+
+// Match はAccept-Languageヘッダーから対応している言語を選択します。
+// 対応している言語が含まれていない場合は def を返します。
+func Match(acceptLanguage string, def Lang) Lang {
+	tags, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(tags) == 0 {
+		return def
+	}
+	_, i, conf := matcher.Match(tags...)
+	if conf == language.No {
+		return def
+	}
+	return Supported[i]
+}
+
+type ctxKey struct{}
+
+// NewContext は言語を設定したcontextを返します。
+func NewContext(ctx context.Context, lang Lang) context.Context {
+	return context.WithValue(ctx, ctxKey{}, lang)
+}
+
+// FromContext はcontextに設定された言語を返します。設定されていない場合は Default を返します。
+func FromContext(ctx context.Context) Lang {
+	if lang, ok := ctx.Value(ctxKey{}).(Lang); ok {
+		return lang
+	}
+	return Default
+}
@@ -0,0 +1,26 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Match(t *testing.T) {
+	tests := []struct {
+		accept   string
+		expected Lang
+	}{
+		{accept: "", expected: Japanese},
+		{accept: "en-US,en;q=0.9", expected: English},
+		{accept: "ja,en;q=0.8", expected: Japanese},
+		{accept: "fr-FR,en;q=0.5", expected: English},
+		{accept: "fr-FR", expected: Japanese},
+		{accept: "invalid;;", expected: Japanese},
+	}
+	for _, tt := range tests {
+		t.Run(tt.accept, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Match(tt.accept, Japanese))
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package i18n
+
+// messages はメッセージキーごとの言語別のメッセージです。
+var messages = map[string]map[Lang]string{
+	"error.invalidRequest": {
+		Japanese: "リクエストの形式が不正です",
+		English:  "The request is invalid",
+	},
+	"error.unauthenticated": {
+		Japanese: "認証が必要です",
+		English:  "Authentication is required",
+	},
+	"error.forbidden": {
+		Japanese: "この操作を行う権限がありません",
+		English:  "You do not have permission to perform this operation",
+	},
+	"error.notFound": {
+		Japanese: "データがありません",
+		English:  "The requested data was not found",
+	},
+	"error.exclusion": {
+		Japanese: "すでに削除されています",
+		English:  "The data has already been deleted",
+	},
+	"error.conflict": {
+		Japanese: "他のユーザーによって更新されています。最新のデータを取得してから再度実行してください",
+		English:  "The data has been updated by another user. Please reload and try again",
+	},
+	"error.duplicate": {
+		Japanese: "既に登録されています",
+		English:  "The data already exists",
+	},
+	"error.preconditionFailed": {
+		Japanese: "データが更新されているため処理できません",
+		English:  "The precondition failed because the data has been modified",
+	},
+	"error.payloadTooLarge": {
+		Japanese: "リクエストのサイズが上限を超えています",
+		English:  "The request payload is too large",
+	},
+	"error.businessRuleViolation": {
+		Japanese: "業務ルールに違反しているため処理できません",
+		English:  "The request violates a business rule",
+	},
+	"error.tooManyRequests": {
+		Japanese: "リクエストが多すぎます。しばらく待ってから再度実行してください",
+		English:  "Too many requests. Please wait a moment and try again",
+	},
+	"error.internal": {
+		Japanese: "想定外のエラーが発生しました",
+		English:  "An unexpected error occurred",
+	},
+	"error.serviceUnavailable": {
+		Japanese: "現在サービスを利用できません。しばらく待ってから再度実行してください",
+		English:  "The service is temporarily unavailable. Please try again later",
+	},
+	"error.clientClosedRequest": {
+		Japanese: "リクエストが中断されました",
+		English:  "The request was canceled by the client",
+	},
+	"error.timeout": {
+		Japanese: "処理が時間内に完了しませんでした。しばらく待ってから再度実行してください",
+		English:  "The request timed out. Please try again later",
+	},
+	"error.unknown": {
+		Japanese: "不明なエラーです",
+		English:  "Unknown error",
+	},
+}
+
+// Message はメッセージキーに対応する指定した言語のメッセージを返します。
+// 翻訳がない場合はデフォルトの言語のメッセージを、キーが未定義の場合はキーをそのまま返します。
+func Message(lang Lang, key string) string {
+	m, ok := messages[key]
+	if !ok {
+		return key
+	}
+	if s, ok := m[lang]; ok {
+		return s
+	}
+	return m[Default]
+}
+
+// HasMessage はメッセージキーに全ての言語のメッセージが定義されているかを返します。
+func HasMessage(key string) bool {
+	m, ok := messages[key]
+	if !ok {
+		return false
+	}
+	for _, lang := range Supported {
+		if m[lang] == "" {
+			return false
+		}
+	}
+	return true
+}
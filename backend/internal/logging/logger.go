@@ -0,0 +1,71 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+)
+
+// 実行中に SetLevel で変更できるよう、ログレベルは LevelVar で保持します。
+var level slog.LevelVar
+
+// New は指定したレベルと形式で出力するロガーを生成します。
+// format には "json" または "text" を指定します。
+func New(w io.Writer, lv, format string) (*slog.Logger, error) {
+	if err := SetLevel(lv); err != nil {
+		return nil, err
+	}
+
+	opts := &slog.HandlerOptions{Level: &level}
+
+	var h slog.Handler
+	switch strings.ToLower(format) {
+	case "", "json":
+		h = slog.NewJSONHandler(w, opts)
+	case "text":
+		h = slog.NewTextHandler(w, opts)
+	default:
+		return nil, fmt.Errorf("unknown log format: %q", format)
+	}
+	return slog.New(h), nil
+}
+
+// SetLevel は New で生成したロガーのログレベルを変更します。
+func SetLevel(s string) error {
+	lv, err := ParseLevel(s)
+	if err != nil {
+		return err
+	}
+	level.Set(lv)
+	return nil
+}
+
+// ParseLevel はログレベルの文字列を slog.Level に変換します。
+func ParseLevel(s string) (slog.Level, error) {
+	var lv slog.Level
+	if s == "" {
+		return slog.LevelInfo, nil
+	}
+	if err := lv.UnmarshalText([]byte(s)); err != nil {
+		return lv, fmt.Errorf("unknown log level: %q", s)
+	}
+	return lv, nil
+}
+
+type loggerKey struct{}
+
+// NewContext はロガーを保持したcontextを返します。
+func NewContext(ctx context.Context, l *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, l)
+}
+
+// FromContext はcontextに保持されたリクエストスコープのロガーを返します。
+// 保持されていない場合は slog.Default() を返します。
+func FromContext(ctx context.Context) *slog.Logger {
+	if l, ok := ctx.Value(loggerKey{}).(*slog.Logger); ok {
+		return l
+	}
+	return slog.Default()
+}
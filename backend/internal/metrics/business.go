@@ -0,0 +1,57 @@
+package metrics
+
+import (
+	"context"
+	"log/slog"
+	"react-ts/backend/internal/domain"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// 集計処理のタイムアウト
+const collectTimeout = 5 * time.Second
+
+var (
+	customersDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "customers"),
+		"Number of customers by visit status.",
+		[]string{"status"}, nil,
+	)
+	unassignedWorkZonesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "unassigned_work_zones"),
+		"Number of work zones without an assigned surveyor.",
+		nil, nil,
+	)
+)
+
+// BusinessCollector はスクレイプ時に業務状況を集計してゲージとして公開するCollectorです
+type BusinessCollector struct {
+	uc domain.StatisticsUseCase
+}
+
+func NewBusinessCollector(uc domain.StatisticsUseCase) *BusinessCollector {
+	return &BusinessCollector{uc: uc}
+}
+
+func (b *BusinessCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- customersDesc
+	ch <- unassignedWorkZonesDesc
+}
+
+func (b *BusinessCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), collectTimeout)
+	defer cancel()
+
+	st, err := b.uc.GetStatistics(ctx)
+	if err != nil {
+		// 集計に失敗した場合は他のメトリクスに影響しないよう、業務メトリクスのみ出力しない
+		slog.ErrorContext(ctx, "failed to collect business metrics", "error", err)
+		return
+	}
+
+	for status, n := range st.CustomersByStatus {
+		ch <- prometheus.MustNewConstMetric(customersDesc, prometheus.GaugeValue, float64(n), string(status))
+	}
+	ch <- prometheus.MustNewConstMetric(unassignedWorkZonesDesc, prometheus.GaugeValue, float64(st.UnassignedWorkZones))
+}
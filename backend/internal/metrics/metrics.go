@@ -0,0 +1,60 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const namespace = "backend"
+
+var (
+	// HTTPRequestsTotal はHTTPリクエスト数のカウンターです
+	HTTPRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "Number of HTTP requests by route template and status.",
+	}, []string{"method", "route", "status"})
+
+	// HTTPRequestDuration はHTTPリクエストの処理時間のヒストグラムです
+	HTTPRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Latency of HTTP requests by route template and status.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	// ErrorResponsesTotal はエラーレスポンス数のカウンターです（errs.ErrorCode単位）
+	ErrorResponsesTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "error_responses_total",
+		Help:      "Number of error responses by error code.",
+	}, []string{"code"})
+
+	// PanicsTotal はリクエストの処理中に回復したpanicの数のカウンターです
+	PanicsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "panics_total",
+		Help:      "Number of panics recovered while handling requests by route template.",
+	}, []string{"route"})
+
+	// CacheRequestsTotal はリポジトリのキャッシュの参照数のカウンターです（result は hit, miss）
+	CacheRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "cache_requests_total",
+		Help:      "Number of repository cache lookups by cache name and result.",
+	}, []string{"cache", "result"})
+
+	// CacheEvictionsTotal はキャッシュの上限を超えたため破棄したエントリー数のカウンターです
+	CacheEvictionsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "cache_evictions_total",
+		Help:      "Number of repository cache entries evicted because the cache was full.",
+	}, []string{"cache"})
+
+	// EventSubscribers は通知の種類（stream: events, positions）ごとの購読している接続数のゲージです
+	EventSubscribers = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "event_subscribers",
+		Help:      "Number of active event subscriptions by stream.",
+	}, []string{"stream"})
+)
@@ -0,0 +1,108 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// Limit はトークンバケットの設定です。
+type Limit struct {
+	// 1秒あたりに補充するトークン数
+	Rate float64
+	// バケットの容量（連続して許可するリクエスト数）
+	Burst int
+}
+
+// Result はトークンを取得した結果です。
+type Result struct {
+	Allowed bool
+	Limit   int
+	// 残りのトークン数
+	Remaining int
+	// バケットが満杯に戻るまでの時間
+	Reset time.Duration
+	// 拒否された場合に次のトークンが補充されるまでの時間
+	RetryAfter time.Duration
+}
+
+// Store はクライアントごとのトークンバケットを保持します。
+// 複数のインスタンスで共有する場合は共有のバックエンド（Redisなど）を使用する実装に差し替えてください。
+type Store interface {
+	// Take は key のバケットからトークンを1つ取得します。
+	Take(ctx context.Context, key string, limit Limit) (Result, error)
+}
+
+// sweepInterval は使われなくなったバケットを削除する間隔です。
+const sweepInterval = time.Minute
+
+// MemoryStore はプロセス内のメモリにバケットを保持するStoreです。
+type MemoryStore struct {
+	now func() time.Time
+
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+	// バケットが満杯に戻る時刻（これを過ぎたバケットは削除しても結果が変わらない）
+	full time.Time
+}
+
+// NewMemoryStore はMemoryStoreを生成します。
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{now: time.Now, buckets: map[string]*bucket{}}
+}
+
+// Take は key のバケットからトークンを1つ取得します。
+func (s *MemoryStore) Take(_ context.Context, key string, limit Limit) (Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.sweep(now)
+
+	burst := float64(limit.Burst)
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: burst, last: now}
+		s.buckets[key] = b
+	}
+
+	// 前回からの経過時間分を補充する（設定の変更で容量が減った場合は切り詰める）
+	b.tokens = min(burst, b.tokens+now.Sub(b.last).Seconds()*limit.Rate)
+	b.last = now
+
+	res := Result{Limit: limit.Burst}
+	if b.tokens >= 1 {
+		b.tokens--
+		res.Allowed = true
+	} else {
+		res.RetryAfter = seconds((1 - b.tokens) / limit.Rate)
+	}
+	res.Remaining = int(math.Floor(b.tokens))
+	res.Reset = seconds((burst - b.tokens) / limit.Rate)
+	b.full = now.Add(res.Reset)
+	return res, nil
+}
+
+// sweep は満杯に戻ったバケットを削除します。
+func (s *MemoryStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < sweepInterval {
+		return
+	}
+	s.lastSweep = now
+	for k, b := range s.buckets {
+		if now.After(b.full) {
+			delete(s.buckets, k)
+		}
+	}
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
@@ -0,0 +1,39 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_MemoryStore_Take(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := NewMemoryStore()
+	s.now = func() time.Time { return now }
+	ctx := context.Background()
+	limit := Limit{Rate: 1, Burst: 2}
+
+	res, _ := s.Take(ctx, "a", limit)
+	assert.Equal(t, Result{Allowed: true, Limit: 2, Remaining: 1, Reset: time.Second}, res)
+	res, _ = s.Take(ctx, "a", limit)
+	assert.Equal(t, Result{Allowed: true, Limit: 2, Remaining: 0, Reset: 2 * time.Second}, res)
+	res, _ = s.Take(ctx, "a", limit)
+	assert.Equal(t, Result{Allowed: false, Limit: 2, Remaining: 0, Reset: 2 * time.Second, RetryAfter: time.Second}, res)
+
+	// 別のキーは別に計数される
+	res, _ = s.Take(ctx, "b", limit)
+	assert.True(t, res.Allowed)
+
+	// 経過時間分のトークンが補充される
+	now = now.Add(1500 * time.Millisecond)
+	res, _ = s.Take(ctx, "a", limit)
+	assert.True(t, res.Allowed)
+	assert.Equal(t, 0, res.Remaining)
+
+	// 満杯に戻ったバケットは削除される
+	now = now.Add(time.Hour)
+	s.Take(ctx, "c", limit)
+	assert.Len(t, s.buckets, 1)
+}
@@ -0,0 +1,61 @@
+// Package cached はリポジトリの参照結果をキャッシュするデコレーターです。
+//
+// Store はリポジトリの参照メソッドの結果を、メソッド名と検索条件をキーとしてキャッシュする汎用の読み込み処理です。
+// 各デコレーターは domain のリポジトリのインターフェースを埋め込んで全てのメソッドを元のリポジトリに委譲し、
+// キャッシュする参照メソッドのみを Get で包みます。Store は domain.CacheInvalidator を実装します。
+//
+// トランザクション内の参照は更新と整合させるため、キャッシュを使用せずに元のリポジトリを呼び出します。
+package cached
+
+import (
+	"context"
+	"react-ts/backend/config"
+	"react-ts/backend/internal/cache"
+	"react-ts/backend/internal/domain"
+)
+
+// Nop は何もしない domain.CacheInvalidator です（キャッシュが無効な場合に使用します）
+type Nop struct{}
+
+var _ domain.CacheInvalidator = Nop{}
+
+func (Nop) InvalidateCache(ctx context.Context) {}
+
+// key はキャッシュのキーです（検索条件は比較可能な値であること）
+type key struct {
+	method string
+	filter any
+}
+
+// Store は1つのリポジトリの参照結果をキャッシュします
+type Store struct {
+	cache *cache.Cache[key, any]
+}
+
+var _ domain.CacheInvalidator = (*Store)(nil)
+
+// NewStore はキャッシュを生成します。name はメトリクスのラベルに使用します
+func NewStore(name string, cfg config.CacheConfig) *Store {
+	return &Store{cache: cache.New[key, any](name, cfg.Size, cfg.TTL, cfg.LoadTimeout)}
+}
+
+// Get はメソッド method の filter に対する結果をキャッシュから返し、キャッシュされていない場合は load で読み込んでキャッシュします
+func Get[F comparable, V any](ctx context.Context, s *Store, method string, filter F, load func(ctx context.Context, filter F) (V, error)) (V, error) {
+	if domain.InTx(ctx) {
+		return load(ctx, filter)
+	}
+	v, err := s.cache.Get(ctx, key{method: method, filter: filter}, func(ctx context.Context) (any, error) {
+		return load(ctx, filter)
+	})
+	if err != nil {
+		var zero V
+		return zero, err
+	}
+	return v.(V), nil
+}
+
+// InvalidateCache は全てのメソッド・検索条件のキャッシュを破棄します
+// 1件の更新が複数の検索条件（事業所単位・ID単位など）の結果に影響するため、全て破棄します
+func (s *Store) InvalidateCache(ctx context.Context) {
+	s.cache.InvalidateAll()
+}
@@ -0,0 +1,63 @@
+package cached
+
+import (
+	"context"
+	"react-ts/backend/config"
+	"react-ts/backend/internal/domain"
+	"react-ts/backend/internal/repository/memtx"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type stubWorkZoneRepository struct {
+	domain.WorkZoneRepository
+	calls map[string]int
+}
+
+func (r *stubWorkZoneRepository) GetWorkZone(ctx context.Context, id string) (domain.WorkZone, error) {
+	r.calls["GetWorkZone/"+id]++
+	return domain.WorkZone{ID: id}, nil
+}
+
+func (r *stubWorkZoneRepository) GetWorkZones(ctx context.Context, filter domain.WorkZoneFilter) (domain.WorkZones, error) {
+	r.calls["GetWorkZones/"+filter.OfficeID]++
+	return domain.WorkZones{{ID: "Z00001", OfficeID: filter.OfficeID}}, nil
+}
+
+func (r *stubWorkZoneRepository) CountUnassignedWorkZones(ctx context.Context) (int, error) {
+	r.calls["CountUnassignedWorkZones"]++
+	return 1, nil
+}
+
+func Test_WorkZoneRepository(t *testing.T) {
+	ctx := context.Background()
+	stub := &stubWorkZoneRepository{calls: map[string]int{}}
+	repo := NewWorkZoneRepository(stub, config.CacheConfig{Enabled: true, Size: 10, TTL: time.Minute, LoadTimeout: time.Second})
+
+	for range 3 {
+		z, err := repo.GetWorkZone(ctx, "Z00001")
+		assert.NoError(t, err)
+		assert.Equal(t, "Z00001", z.ID)
+		zs, err := repo.GetWorkZones(ctx, domain.WorkZoneFilter{OfficeID: "XX"})
+		assert.NoError(t, err)
+		assert.Len(t, zs, 1)
+		repo.CountUnassignedWorkZones(ctx)
+	}
+	// メソッドと検索条件ごとにキャッシュされ、集計はキャッシュしない
+	assert.Equal(t, map[string]int{"GetWorkZone/Z00001": 1, "GetWorkZones/XX": 1, "CountUnassignedWorkZones": 3}, stub.calls)
+
+	// トランザクション内の参照はキャッシュを使用しない
+	memtx.New().WithinTx(ctx, func(ctx context.Context) error {
+		_, err := repo.GetWorkZone(ctx, "Z00001")
+		return err
+	})
+	assert.Equal(t, 2, stub.calls["GetWorkZone/Z00001"])
+
+	repo.InvalidateCache(ctx)
+	repo.GetWorkZone(ctx, "Z00001")
+	repo.GetWorkZones(ctx, domain.WorkZoneFilter{OfficeID: "XX"})
+	assert.Equal(t, 3, stub.calls["GetWorkZone/Z00001"])
+	assert.Equal(t, 2, stub.calls["GetWorkZones/XX"])
+}
@@ -0,0 +1,28 @@
+package cached
+
+import (
+	"context"
+	"react-ts/backend/config"
+	"react-ts/backend/internal/domain"
+)
+
+// OfficeRepository は事業所の一覧をキャッシュします
+type OfficeRepository struct {
+	domain.OfficeRepository
+	*Store
+}
+
+var (
+	_ domain.OfficeRepository = (*OfficeRepository)(nil)
+	_ domain.CacheInvalidator = (*OfficeRepository)(nil)
+)
+
+func NewOfficeRepository(repo domain.OfficeRepository, cfg config.CacheConfig) *OfficeRepository {
+	return &OfficeRepository{OfficeRepository: repo, Store: NewStore("offices", cfg)}
+}
+
+func (r *OfficeRepository) GetOffices(ctx context.Context) (domain.Offices, error) {
+	return Get(ctx, r.Store, "GetOffices", struct{}{}, func(ctx context.Context, _ struct{}) (domain.Offices, error) {
+		return r.OfficeRepository.GetOffices(ctx)
+	})
+}
@@ -0,0 +1,27 @@
+package cached
+
+import (
+	"context"
+	"react-ts/backend/config"
+	"react-ts/backend/internal/domain"
+)
+
+// SurveyRepository は調査員の検索結果を検索条件ごとにキャッシュします
+// バージョンはキャッシュした値では変更を検出できないため、キャッシュせずに元のリポジトリを呼び出します
+type SurveyRepository struct {
+	domain.SurveyRepository
+	*Store
+}
+
+var (
+	_ domain.SurveyRepository = (*SurveyRepository)(nil)
+	_ domain.CacheInvalidator = (*SurveyRepository)(nil)
+)
+
+func NewSurveyRepository(repo domain.SurveyRepository, cfg config.CacheConfig) *SurveyRepository {
+	return &SurveyRepository{SurveyRepository: repo, Store: NewStore("surveyors", cfg)}
+}
+
+func (r *SurveyRepository) GetSurveyors(ctx context.Context, filter domain.SurveyorFilter) (domain.Surveyors, error) {
+	return Get(ctx, r.Store, "GetSurveyors", filter, r.SurveyRepository.GetSurveyors)
+}
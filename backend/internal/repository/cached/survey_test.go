@@ -0,0 +1,45 @@
+package cached
+
+import (
+	"context"
+	"react-ts/backend/config"
+	"react-ts/backend/internal/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type stubSurveyRepository struct {
+	calls map[domain.SurveyorFilter]int
+}
+
+func (r *stubSurveyRepository) GetSurveyors(ctx context.Context, filter domain.SurveyorFilter) (domain.Surveyors, error) {
+	r.calls[filter]++
+	return domain.Surveyors{{ID: filter.ID, OfficeID: filter.OfficeID}}, nil
+}
+
+func (r *stubSurveyRepository) GetSurveyorsVersion(ctx context.Context) (string, error) {
+	return "1", nil
+}
+
+func Test_SurveyRepository(t *testing.T) {
+	ctx := context.Background()
+	stub := &stubSurveyRepository{calls: map[domain.SurveyorFilter]int{}}
+	repo := NewSurveyRepository(stub, config.CacheConfig{Enabled: true, Size: 10, TTL: time.Minute, LoadTimeout: time.Second})
+
+	xx := domain.SurveyorFilter{OfficeID: "XX"}
+	yy := domain.SurveyorFilter{OfficeID: "YY"}
+	for range 3 {
+		md, err := repo.GetSurveyors(ctx, xx)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.Surveyors{{OfficeID: "XX"}}, md)
+	}
+	repo.GetSurveyors(ctx, yy)
+	// 検索条件ごとにキャッシュされる
+	assert.Equal(t, map[domain.SurveyorFilter]int{xx: 1, yy: 1}, stub.calls)
+
+	repo.InvalidateCache(ctx)
+	repo.GetSurveyors(ctx, xx)
+	assert.Equal(t, 2, stub.calls[xx])
+}
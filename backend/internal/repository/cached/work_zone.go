@@ -0,0 +1,31 @@
+package cached
+
+import (
+	"context"
+	"react-ts/backend/config"
+	"react-ts/backend/internal/domain"
+)
+
+// WorkZoneRepository は作業区の検索結果を検索条件ごとにキャッシュします
+// 未割り当ての作業区の数は業務メトリクスで最新の値を集計するため、キャッシュせずに元のリポジトリを呼び出します
+type WorkZoneRepository struct {
+	domain.WorkZoneRepository
+	*Store
+}
+
+var (
+	_ domain.WorkZoneRepository = (*WorkZoneRepository)(nil)
+	_ domain.CacheInvalidator   = (*WorkZoneRepository)(nil)
+)
+
+func NewWorkZoneRepository(repo domain.WorkZoneRepository, cfg config.CacheConfig) *WorkZoneRepository {
+	return &WorkZoneRepository{WorkZoneRepository: repo, Store: NewStore("work_zones", cfg)}
+}
+
+func (r *WorkZoneRepository) GetWorkZone(ctx context.Context, id string) (domain.WorkZone, error) {
+	return Get(ctx, r.Store, "GetWorkZone", id, r.WorkZoneRepository.GetWorkZone)
+}
+
+func (r *WorkZoneRepository) GetWorkZones(ctx context.Context, filter domain.WorkZoneFilter) (domain.WorkZones, error) {
+	return Get(ctx, r.Store, "GetWorkZones", filter, r.WorkZoneRepository.GetWorkZones)
+}
@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"react-ts/backend/internal/domain"
+	"slices"
+)
+
+// TODO repositoryの実装
+
+func NewCustomerRepository() domain.CustomerRepository {
+	return &customerRepository{}
+}
+
+type customerRepository struct {
+}
+
+func (r *customerRepository) CountCustomersByStatus(ctx context.Context) (map[domain.CustomerStatus]int, error) {
+	_, span := tracer.Start(ctx, "CustomerRepository.CountCustomersByStatus")
+	defer span.End()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	//TODO
+	ret := map[domain.CustomerStatus]int{
+		domain.CustomerStatusNotVisited: 2,
+		domain.CustomerStatusVisited:    1,
+		domain.CustomerStatusAbsent:     0,
+	}
+	return ret, nil
+}
+
+func (r *customerRepository) GetCustomers(ctx context.Context, filter domain.CustomerFilter) (domain.Customers, error) {
+	_, span := tracer.Start(ctx, "CustomerRepository.GetCustomers")
+	defer span.End()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	//TODO
+	customers := domain.Customers{
+		{ID: "C00001", Name: "お客さま1", Lat: 35.681236, Lng: 139.767125, SurveyorID: "000001", WorkZoneID: "Z00001", Status: domain.CustomerStatusNotVisited},
+		{ID: "C00002", Name: "お客さま2", Lat: 35.689487, Lng: 139.691706, SurveyorID: "000001", WorkZoneID: "Z00001", Status: domain.CustomerStatusVisited},
+		{ID: "C00003", Name: "お客さま3", Lat: 35.658034, Lng: 139.701636, SurveyorID: "000002", WorkZoneID: "Z00002", Status: domain.CustomerStatusNotVisited},
+	}
+	var ret domain.Customers
+	for _, c := range customers {
+		if (len(filter.IDs) == 0 || slices.Contains(filter.IDs, c.ID)) && (filter.SurveyorID == "" || c.SurveyorID == filter.SurveyorID) {
+			ret = append(ret, c)
+		}
+	}
+	return ret, nil
+}
+
+func (r *customerRepository) UpdateCustomerLocation(ctx context.Context, m domain.CustomerMoved) error {
+	_, span := tracer.Start(ctx, "CustomerRepository.UpdateCustomerLocation")
+	defer span.End()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	//TODO
+	return nil
+}
+
+func (r *customerRepository) UpdateCustomerStatus(ctx context.Context, id string, status domain.CustomerStatus) error {
+	_, span := tracer.Start(ctx, "CustomerRepository.UpdateCustomerStatus")
+	defer span.End()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	//TODO
+	return nil
+}
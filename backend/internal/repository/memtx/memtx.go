@@ -0,0 +1,76 @@
+// Package memtx はメモリ上のデータのみを扱うリポジトリ向けのTxManagerです。
+//
+// 調査員の最新の位置や軌跡など、データベースに保存しないデータのみを扱う間は本番でも使用します。
+// リポジトリは変更を行う際に domain.OnRollback で変更を取り消す処理を登録します。
+// トランザクションがロールバックされると、登録された処理を登録の逆順に実行します。
+package memtx
+
+import (
+	"context"
+	"react-ts/backend/internal/domain"
+	"sync/atomic"
+)
+
+type txKey struct{}
+
+// Manager はメモリ上のTxManagerです
+type Manager struct {
+	// コミット・ロールバックしたトランザクションの数（セーブポイントを除く）
+	Commits   atomic.Int64
+	Rollbacks atomic.Int64
+}
+
+var _ domain.TxManager = (*Manager)(nil)
+
+// New はManagerを生成します
+func New() *Manager {
+	return &Manager{}
+}
+
+func (m *Manager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	if h, ok := ctx.Value(txKey{}).(*domain.RollbackHooks); ok {
+		return withinSavepoint(ctx, h, fn)
+	}
+
+	h := &domain.RollbackHooks{}
+	defer func() {
+		if p := recover(); p != nil {
+			h.RollbackTo(0)
+			m.Rollbacks.Add(1)
+			panic(p)
+		}
+	}()
+
+	ctx = domain.WithRollbackHooks(context.WithValue(ctx, txKey{}, h), h)
+	if err := fn(ctx); err != nil {
+		h.RollbackTo(0)
+		m.Rollbacks.Add(1)
+		return err
+	}
+	m.Commits.Add(1)
+	return nil
+}
+
+// withinSavepoint は fn が失敗した場合に、fn の実行中に登録された処理のみを取り消します
+func withinSavepoint(ctx context.Context, h *domain.RollbackHooks, fn func(ctx context.Context) error) error {
+	savepoint := h.Savepoint()
+
+	defer func() {
+		if p := recover(); p != nil {
+			h.RollbackTo(savepoint)
+			panic(p)
+		}
+	}()
+
+	if err := fn(ctx); err != nil {
+		h.RollbackTo(savepoint)
+		return err
+	}
+	return nil
+}
+
+// InTx は ctx がトランザクション内かどうかを返します
+func InTx(ctx context.Context) bool {
+	_, ok := ctx.Value(txKey{}).(*domain.RollbackHooks)
+	return ok
+}
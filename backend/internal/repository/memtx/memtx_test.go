@@ -0,0 +1,96 @@
+package memtx
+
+import (
+	"context"
+	"errors"
+	"react-ts/backend/internal/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// store はトランザクションに参加するメモリ上のリポジトリです
+type store struct {
+	values map[string]int
+}
+
+func (s *store) set(ctx context.Context, key string, v int) {
+	old, ok := s.values[key]
+	domain.OnRollback(ctx, func() {
+		if ok {
+			s.values[key] = old
+		} else {
+			delete(s.values, key)
+		}
+	})
+	s.values[key] = v
+}
+
+func Test_Manager(t *testing.T) {
+	ctx := context.Background()
+	errFailed := errors.New("failed")
+
+	t.Run("Commit", func(t *testing.T) {
+		m, s := New(), &store{values: map[string]int{}}
+		err := m.WithinTx(ctx, func(ctx context.Context) error {
+			assert.True(t, InTx(ctx))
+			s.set(ctx, "a", 1)
+			s.set(ctx, "b", 2)
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]int{"a": 1, "b": 2}, s.values)
+		assert.EqualValues(t, 1, m.Commits.Load())
+	})
+
+	t.Run("Rollback", func(t *testing.T) {
+		m, s := New(), &store{values: map[string]int{"a": 0}}
+		err := m.WithinTx(ctx, func(ctx context.Context) error {
+			s.set(ctx, "a", 1)
+			s.set(ctx, "a", 2)
+			s.set(ctx, "b", 3)
+			return errFailed
+		})
+		assert.ErrorIs(t, err, errFailed)
+		assert.Equal(t, map[string]int{"a": 0}, s.values)
+		assert.EqualValues(t, 1, m.Rollbacks.Load())
+	})
+
+	t.Run("Savepoint", func(t *testing.T) {
+		m, s := New(), &store{values: map[string]int{}}
+		err := m.WithinTx(ctx, func(ctx context.Context) error {
+			s.set(ctx, "a", 1)
+			// 入れ子のトランザクションの失敗は外側のトランザクションに影響しない
+			inner := m.WithinTx(ctx, func(ctx context.Context) error {
+				s.set(ctx, "b", 2)
+				return errFailed
+			})
+			assert.ErrorIs(t, inner, errFailed)
+			return m.WithinTx(ctx, func(ctx context.Context) error {
+				s.set(ctx, "c", 3)
+				return nil
+			})
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]int{"a": 1, "c": 3}, s.values)
+	})
+
+	t.Run("Panic", func(t *testing.T) {
+		m, s := New(), &store{values: map[string]int{}}
+		assert.Panics(t, func() {
+			m.WithinTx(ctx, func(ctx context.Context) error {
+				s.set(ctx, "a", 1)
+				panic("boom")
+			})
+		})
+		assert.Empty(t, s.values)
+		assert.EqualValues(t, 1, m.Rollbacks.Load())
+	})
+
+	t.Run("WithoutTx", func(t *testing.T) {
+		s := &store{values: map[string]int{}}
+		s.set(ctx, "a", 1)
+		assert.False(t, InTx(ctx))
+		assert.Equal(t, map[string]int{"a": 1}, s.values)
+	})
+}
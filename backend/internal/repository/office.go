@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"context"
+	"react-ts/backend/internal/domain"
+)
+
+// TODO repositoryの実装
+
+func NewOfficeRepository() domain.OfficeRepository {
+	return &officeRepository{}
+}
+
+type officeRepository struct {
+}
+
+func (r *officeRepository) GetOffices(ctx context.Context) (domain.Offices, error) {
+	_, span := tracer.Start(ctx, "OfficeRepository.GetOffices")
+	defer span.End()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	//TODO
+	ret := domain.Offices{
+		{ID: "XX", Name: "〇〇事業所"},
+		{ID: "YY", Name: "△△事業所"},
+	}
+	return ret, nil
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"react-ts/backend/internal/domain"
+	"slices"
+	"sync"
+)
+
+// NewPositionRepository は調査員の最新の位置をメモリに保持するリポジトリを生成します
+// 最新の位置は頻繁に更新され、再起動で失われても次の測位で復元されるため、データベースには保存しません
+func NewPositionRepository() domain.PositionRepository {
+	return &positionRepository{positions: map[string]domain.Position{}}
+}
+
+type positionRepository struct {
+	mu        sync.RWMutex
+	positions map[string]domain.Position
+}
+
+func (r *positionRepository) SaveLatestPosition(ctx context.Context, p domain.Position) error {
+	_, span := tracer.Start(ctx, "PositionRepository.SaveLatestPosition")
+	defer span.End()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	prev, ok := r.positions[p.SurveyorID]
+	r.positions[p.SurveyorID] = p
+	domain.OnRollback(ctx, func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		if ok {
+			r.positions[p.SurveyorID] = prev
+		} else {
+			delete(r.positions, p.SurveyorID)
+		}
+	})
+	return nil
+}
+
+func (r *positionRepository) GetLatestPosition(ctx context.Context, surveyorID string) (domain.Position, error) {
+	_, span := tracer.Start(ctx, "PositionRepository.GetLatestPosition")
+	defer span.End()
+
+	if err := ctx.Err(); err != nil {
+		return domain.Position{}, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	p, ok := r.positions[surveyorID]
+	if !ok {
+		return domain.Position{}, fmt.Errorf("position of surveyor %s: %w", surveyorID, domain.ErrNotFound)
+	}
+	return p, nil
+}
+
+func (r *positionRepository) GetLatestPositions(ctx context.Context, officeID string) (domain.Positions, error) {
+	_, span := tracer.Start(ctx, "PositionRepository.GetLatestPositions")
+	defer span.End()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var ret domain.Positions
+	for _, p := range r.positions {
+		if officeID == "" || p.OfficeID == officeID {
+			ret = append(ret, p)
+		}
+	}
+	slices.SortFunc(ret, func(a, b domain.Position) int {
+		return cmp.Compare(a.SurveyorID, b.SurveyorID)
+	})
+	return ret, nil
+}
@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+)
+
+var tracer = otel.Tracer("react-ts/backend/internal/repository")
+
+// Ping はデータストアへの接続を確認します
+func Ping(ctx context.Context) error {
+	//TODO データベース接続の確認
+	return ctx.Err()
+}
+
+// Close はデータストアへの接続を解放します
+func Close(ctx context.Context) error {
+	//TODO データベース接続の切断
+	return nil
+}
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"react-ts/backend/internal/domain"
 )
 
@@ -13,7 +14,14 @@ func NewSamplesRepository() domain.SampleRepository {
 type repository struct {
 }
 
-func (r *repository) GetSamples() (domain.Samples, error) {
+func (r *repository) GetSamples(ctx context.Context) (domain.Samples, error) {
+	_, span := tracer.Start(ctx, "SampleRepository.GetSamples")
+	defer span.End()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	//TODO
 	ret := domain.Samples{
 		{ID: "01", Name: "サンプル1"},
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"react-ts/backend/internal/domain"
 )
 
@@ -13,7 +14,14 @@ func NewSurveyRepository() domain.SurveyRepository {
 type surveyRepository struct {
 }
 
-func (r *surveyRepository) GetSurveyors(filter domain.SurveyorFilter) (domain.Surveyors, error) {
+func (r *surveyRepository) GetSurveyors(ctx context.Context, filter domain.SurveyorFilter) (domain.Surveyors, error) {
+	_, span := tracer.Start(ctx, "SurveyRepository.GetSurveyors")
+	defer span.End()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	//TODO
 	ret := domain.Surveyors{
 		{ID: "000001", Name: "調査員1", OfficeID: "XX", OfficeName: "〇〇事業所"},
@@ -21,3 +29,15 @@ func (r *surveyRepository) GetSurveyors(filter domain.SurveyorFilter) (domain.Su
 	}
 	return ret, nil
 }
+
+func (r *surveyRepository) GetSurveyorsVersion(ctx context.Context) (string, error) {
+	_, span := tracer.Start(ctx, "SurveyRepository.GetSurveyorsVersion")
+	defer span.End()
+
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	//TODO 更新日時の最大値などから求める
+	return "1", nil
+}
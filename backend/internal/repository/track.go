@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"react-ts/backend/internal/domain"
+	"slices"
+	"sync"
+	"time"
+)
+
+// NewTrackRepository は調査員の軌跡をメモリに保持するリポジトリを生成します
+// 現在日時から retention より古い日付の軌跡は、新しい日付の軌跡を追加した際に削除します
+// TODO データベースに接続したら測位結果のテーブルに保存する
+func NewTrackRepository(retention time.Duration) domain.TrackRepository {
+	return &trackRepository{tracks: map[trackKey]domain.TrackPoints{}, retention: retention, now: time.Now}
+}
+
+type trackKey struct {
+	surveyorID string
+	date       string
+}
+
+type trackRepository struct {
+	mu        sync.RWMutex
+	tracks    map[trackKey]domain.TrackPoints
+	retention time.Duration
+	now       func() time.Time
+}
+
+func newTrackKey(surveyorID string, date time.Time) trackKey {
+	return trackKey{surveyorID: surveyorID, date: date.Format(time.DateOnly)}
+}
+
+func (r *trackRepository) AppendTrackPoint(ctx context.Context, surveyorID string, date time.Time, p domain.TrackPoint) error {
+	_, span := tracer.Start(ctx, "TrackRepository.AppendTrackPoint")
+	defer span.End()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := newTrackKey(surveyorID, date)
+	points, ok := r.tracks[key]
+	if !ok {
+		r.prune()
+	}
+	r.tracks[key] = append(points, p)
+	domain.OnRollback(ctx, func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		if ok {
+			r.tracks[key] = points
+		} else {
+			delete(r.tracks, key)
+		}
+	})
+	return nil
+}
+
+// prune は現在日時から保持期間より古い日付の軌跡を削除します
+// 追加する軌跡の日付は端末の時計に依存するため基準にしません（未来の日付で全員の軌跡が削除されないよう）
+func (r *trackRepository) prune() {
+	if r.retention <= 0 {
+		return
+	}
+	oldest := r.now().Add(-r.retention).Format(time.DateOnly)
+	for key := range r.tracks {
+		if key.date < oldest {
+			delete(r.tracks, key)
+		}
+	}
+}
+
+func (r *trackRepository) GetTrackPoints(ctx context.Context, surveyorID string, date time.Time) (domain.TrackPoints, error) {
+	_, span := tracer.Start(ctx, "TrackRepository.GetTrackPoints")
+	defer span.End()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return slices.Clone(r.tracks[newTrackKey(surveyorID, date)]), nil
+}
@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"context"
+	"react-ts/backend/internal/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_TrackRepository_Prune(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	repo := NewTrackRepository(48 * time.Hour).(*trackRepository)
+	repo.now = func() time.Time { return now }
+
+	today := now
+	day := func(offset int) time.Time { return today.AddDate(0, 0, offset) }
+	appendPoint := func(surveyorID string, date time.Time) {
+		t.Helper()
+		assert.NoError(t, repo.AppendTrackPoint(ctx, surveyorID, date, domain.TrackPoint{RecordedAt: date}))
+	}
+	count := func(surveyorID string, date time.Time) int {
+		t.Helper()
+		points, err := repo.GetTrackPoints(ctx, surveyorID, date)
+		assert.NoError(t, err)
+		return len(points)
+	}
+
+	appendPoint("000001", day(-2))
+	appendPoint("000001", day(-1))
+	appendPoint("000001", day(0))
+
+	// 未来の日付の測位結果を追加しても、他の調査員の軌跡は削除しない
+	appendPoint("000002", day(365))
+	assert.Equal(t, 1, count("000001", day(-2)))
+	assert.Equal(t, 1, count("000001", day(-1)))
+	assert.Equal(t, 1, count("000001", day(0)))
+
+	// 現在日時から保持期間より古い日付の軌跡は削除する
+	now = now.AddDate(0, 0, 2)
+	appendPoint("000002", now)
+	assert.Equal(t, 0, count("000001", day(-2)))
+	assert.Equal(t, 1, count("000001", day(0)))
+}
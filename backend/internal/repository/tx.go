@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"react-ts/backend/internal/domain"
+)
+
+// DBTX は *sql.DB と *sql.Tx に共通するクエリの実行方法です
+type DBTX interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+type txKey struct{}
+
+// txState はcontextで引き継ぐトランザクションです
+type txState struct {
+	tx *sql.Tx
+	// メモリ上のデータを扱うリポジトリが登録した、ロールバック時に実行する処理
+	hooks *domain.RollbackHooks
+	// セーブポイントの入れ子の深さ
+	depth int
+}
+
+// NewTxManager は database/sql のトランザクションを使用するTxManagerを生成します
+func NewTxManager(db *sql.DB) domain.TxManager {
+	return &txManager{db: db}
+}
+
+type txManager struct {
+	db *sql.DB
+}
+
+func (m *txManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	ctx, span := tracer.Start(ctx, "TxManager.WithinTx")
+	defer span.End()
+
+	if st, ok := ctx.Value(txKey{}).(*txState); ok {
+		return withinSavepoint(ctx, st, fn)
+	}
+
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	st := &txState{tx: tx, hooks: &domain.RollbackHooks{}}
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			st.hooks.RollbackTo(0)
+			panic(p)
+		}
+	}()
+
+	ctx = domain.WithRollbackHooks(context.WithValue(ctx, txKey{}, st), st.hooks)
+	if err := fn(ctx); err != nil {
+		st.hooks.RollbackTo(0)
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return errors.Join(err, fmt.Errorf("failed to roll back transaction: %w", rbErr))
+		}
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		// コミットに失敗した場合はデータベースの変更も確定しないため、メモリ上の変更も取り消す
+		st.hooks.RollbackTo(0)
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// withinSavepoint は fn をセーブポイント内で実行し、失敗した場合はセーブポイントまでロールバックします
+func withinSavepoint(ctx context.Context, st *txState, fn func(ctx context.Context) error) error {
+	st.depth++
+	defer func() { st.depth-- }()
+	name := fmt.Sprintf("sp_%d", st.depth)
+	savepoint := st.hooks.Savepoint()
+
+	if _, err := st.tx.ExecContext(ctx, "SAVEPOINT "+name); err != nil {
+		return fmt.Errorf("failed to create savepoint: %w", err)
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			st.tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+name)
+			st.hooks.RollbackTo(savepoint)
+			panic(p)
+		}
+	}()
+
+	if err := fn(ctx); err != nil {
+		st.hooks.RollbackTo(savepoint)
+		if _, rbErr := st.tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+name); rbErr != nil {
+			return errors.Join(err, fmt.Errorf("failed to roll back to savepoint: %w", rbErr))
+		}
+		return err
+	}
+	if _, err := st.tx.ExecContext(ctx, "RELEASE SAVEPOINT "+name); err != nil {
+		return fmt.Errorf("failed to release savepoint: %w", err)
+	}
+	return nil
+}
+
+// conn はcontextにトランザクションがある場合はそのトランザクションを、ない場合は db を返します
+// リポジトリはこれを通じてクエリを実行することで、呼び出し元のトランザクションに透過的に参加します
+func conn(ctx context.Context, db *sql.DB) DBTX {
+	if st, ok := ctx.Value(txKey{}).(*txState); ok {
+		return st.tx
+	}
+	return db
+}
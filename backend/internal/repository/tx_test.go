@@ -0,0 +1,152 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"react-ts/backend/internal/domain"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_TxManager(t *testing.T) {
+	ctx := context.Background()
+	errFailed := errors.New("failed")
+
+	newManager := func(t *testing.T) (domain.TxManager, sqlmock.Sqlmock) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { db.Close() })
+		return NewTxManager(db), mock
+	}
+
+	t.Run("Commit", func(t *testing.T) {
+		m, mock := newManager(t)
+		mock.ExpectBegin()
+		mock.ExpectCommit()
+
+		undone := false
+		err := m.WithinTx(ctx, func(ctx context.Context) error {
+			domain.OnRollback(ctx, func() { undone = true })
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.False(t, undone)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Rollback", func(t *testing.T) {
+		m, mock := newManager(t)
+		mock.ExpectBegin()
+		mock.ExpectRollback()
+
+		var undone []int
+		err := m.WithinTx(ctx, func(ctx context.Context) error {
+			domain.OnRollback(ctx, func() { undone = append(undone, 1) })
+			domain.OnRollback(ctx, func() { undone = append(undone, 2) })
+			return errFailed
+		})
+		assert.ErrorIs(t, err, errFailed)
+		// 登録の逆順に取り消す
+		assert.Equal(t, []int{2, 1}, undone)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("CommitFailed", func(t *testing.T) {
+		m, mock := newManager(t)
+		mock.ExpectBegin()
+		mock.ExpectCommit().WillReturnError(errFailed)
+
+		undone := false
+		err := m.WithinTx(ctx, func(ctx context.Context) error {
+			domain.OnRollback(ctx, func() { undone = true })
+			return nil
+		})
+		assert.ErrorIs(t, err, errFailed)
+		assert.True(t, undone)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Savepoint", func(t *testing.T) {
+		m, mock := newManager(t)
+		mock.ExpectBegin()
+		mock.ExpectExec("SAVEPOINT sp_1").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("SAVEPOINT sp_2").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("ROLLBACK TO SAVEPOINT sp_2").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("RELEASE SAVEPOINT sp_1").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("SAVEPOINT sp_1").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("RELEASE SAVEPOINT sp_1").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		var undone []string
+		err := m.WithinTx(ctx, func(ctx context.Context) error {
+			domain.OnRollback(ctx, func() { undone = append(undone, "a") })
+			err := m.WithinTx(ctx, func(ctx context.Context) error {
+				domain.OnRollback(ctx, func() { undone = append(undone, "b") })
+				// 入れ子のトランザクションの失敗は外側のトランザクションに影響しない
+				inner := m.WithinTx(ctx, func(ctx context.Context) error {
+					domain.OnRollback(ctx, func() { undone = append(undone, "c") })
+					return errFailed
+				})
+				assert.ErrorIs(t, inner, errFailed)
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			// セーブポイントの名前は入れ子の深さごとに再利用する
+			return m.WithinTx(ctx, func(ctx context.Context) error { return nil })
+		})
+		assert.NoError(t, err)
+		// 失敗したセーブポイント内で登録された処理のみを取り消す
+		assert.Equal(t, []string{"c"}, undone)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Panic", func(t *testing.T) {
+		m, mock := newManager(t)
+		mock.ExpectBegin()
+		mock.ExpectRollback()
+
+		undone := false
+		assert.Panics(t, func() {
+			m.WithinTx(ctx, func(ctx context.Context) error {
+				domain.OnRollback(ctx, func() { undone = true })
+				panic("boom")
+			})
+		})
+		assert.True(t, undone)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Conn", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer db.Close()
+		m := NewTxManager(db)
+
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE work_zones SET surveyor_id = ? WHERE id = ?").
+			WithArgs("000002", "Z00001").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		// トランザクション外ではdbを使用する
+		assert.Same(t, db, conn(ctx, db))
+		err = m.WithinTx(ctx, func(ctx context.Context) error {
+			// リポジトリのクエリは呼び出し元のトランザクションで実行される
+			c := conn(ctx, db)
+			assert.IsType(t, &sql.Tx{}, c)
+			_, err := c.ExecContext(ctx, "UPDATE work_zones SET surveyor_id = ? WHERE id = ?", "000002", "Z00001")
+			return err
+		})
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
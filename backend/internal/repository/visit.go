@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+	"react-ts/backend/internal/domain"
+	"time"
+)
+
+// TODO repositoryの実装
+
+func NewVisitRepository() domain.VisitRepository {
+	return &visitRepository{}
+}
+
+type visitRepository struct {
+}
+
+func (r *visitRepository) GetVisits(ctx context.Context, filter domain.VisitFilter) (domain.Visits, error) {
+	_, span := tracer.Start(ctx, "VisitRepository.GetVisits")
+	defer span.End()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	//TODO
+	jst := time.FixedZone("JST", 9*60*60)
+	at := func(hour, min int) time.Time {
+		return time.Date(2026, 10, 19, hour, min, 0, 0, jst)
+	}
+	visits := domain.Visits{
+		{ID: "V00001", CustomerID: "C00001", SurveyorID: "000001", OfficeID: "XX", Status: domain.CustomerStatusVisited, RecordedAt: at(10, 0),
+			Fix: &domain.TrackPoint{Lat: 35.681300, Lng: 139.767200, Accuracy: 8, RecordedAt: at(10, 0)}},
+		{ID: "V00002", CustomerID: "C00002", SurveyorID: "000001", OfficeID: "XX", Status: domain.CustomerStatusAbsent, RecordedAt: at(11, 30),
+			Fix: &domain.TrackPoint{Lat: 35.690500, Lng: 139.692500, Accuracy: 15, RecordedAt: at(11, 30)}},
+		{ID: "V00003", CustomerID: "C00003", SurveyorID: "000002", OfficeID: "XX", Status: domain.CustomerStatusVisited, RecordedAt: at(14, 0),
+			Fix: &domain.TrackPoint{Lat: 35.670000, Lng: 139.702000, Accuracy: 10, RecordedAt: at(14, 0)}},
+		{ID: "V00004", CustomerID: "C00001", SurveyorID: "000001", OfficeID: "XX", Status: domain.CustomerStatusVisited, RecordedAt: at(16, 0)},
+	}
+	var ret domain.Visits
+	for _, v := range visits {
+		if filter.OfficeID != "" && v.OfficeID != filter.OfficeID {
+			continue
+		}
+		if filter.SurveyorID != "" && v.SurveyorID != filter.SurveyorID {
+			continue
+		}
+		if !filter.From.IsZero() && v.RecordedAt.Before(filter.From) || !filter.To.IsZero() && !v.RecordedAt.Before(filter.To) {
+			continue
+		}
+		ret = append(ret, v)
+	}
+	return ret, nil
+}
+
+func (r *visitRepository) SaveVisit(ctx context.Context, v domain.Visit) error {
+	_, span := tracer.Start(ctx, "VisitRepository.SaveVisit")
+	defer span.End()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	//TODO
+	return nil
+}
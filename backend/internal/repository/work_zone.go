@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"react-ts/backend/internal/domain"
+)
+
+// TODO repositoryの実装
+
+func NewWorkZoneRepository() domain.WorkZoneRepository {
+	return &workZoneRepository{}
+}
+
+type workZoneRepository struct {
+}
+
+func (r *workZoneRepository) CountUnassignedWorkZones(ctx context.Context) (int, error) {
+	_, span := tracer.Start(ctx, "WorkZoneRepository.CountUnassignedWorkZones")
+	defer span.End()
+
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	//TODO
+	return 1, nil
+}
+
+// sampleWorkZones は仮の作業区です（TODO データベースから取得する）
+var sampleWorkZones = domain.WorkZones{
+	{ID: "Z00001", Name: "作業区1", OfficeID: "XX", SurveyorID: "000001"},
+	{ID: "Z00002", Name: "作業区2", OfficeID: "XX", SurveyorID: "000002"},
+	{ID: "Z00003", Name: "作業区3", OfficeID: "XX"},
+	{ID: "Z00004", Name: "作業区4", OfficeID: "YY", SurveyorID: "000003"},
+}
+
+func (r *workZoneRepository) GetWorkZones(ctx context.Context, filter domain.WorkZoneFilter) (domain.WorkZones, error) {
+	_, span := tracer.Start(ctx, "WorkZoneRepository.GetWorkZones")
+	defer span.End()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	//TODO
+	var ret domain.WorkZones
+	for _, z := range sampleWorkZones {
+		if filter.OfficeID == "" || z.OfficeID == filter.OfficeID {
+			ret = append(ret, z)
+		}
+	}
+	return ret, nil
+}
+
+func (r *workZoneRepository) GetWorkZone(ctx context.Context, id string) (domain.WorkZone, error) {
+	_, span := tracer.Start(ctx, "WorkZoneRepository.GetWorkZone")
+	defer span.End()
+
+	if err := ctx.Err(); err != nil {
+		return domain.WorkZone{}, err
+	}
+
+	//TODO
+	for _, z := range sampleWorkZones {
+		if z.ID == id {
+			return z, nil
+		}
+	}
+	return domain.WorkZone{}, fmt.Errorf("work zone %s: %w", id, domain.ErrNotFound)
+}
+
+func (r *workZoneRepository) UpdateWorkZoneSurveyor(ctx context.Context, id, surveyorID string) error {
+	_, span := tracer.Start(ctx, "WorkZoneRepository.UpdateWorkZoneSurveyor")
+	defer span.End()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	//TODO
+	return nil
+}
@@ -0,0 +1,20 @@
+package requestid
+
+import "context"
+
+// Header はリクエストIDを受け渡すHTTPヘッダー名です。
+const Header = "X-Request-ID"
+
+type requestIDKey struct{}
+
+// NewContext はリクエストIDを保持したcontextを返します。
+func NewContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// FromContext はcontextに保持されたリクエストIDを返します。
+// 保持されていない場合は空文字を返します。
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
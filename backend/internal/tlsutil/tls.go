@@ -0,0 +1,142 @@
+package tlsutil
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"react-ts/backend/config"
+	"sync/atomic"
+	"time"
+)
+
+// NewConfig は設定からサーバー用の tls.Config を生成します。
+// 証明書ファイルを使用する場合は、ファイルの更新を監視する CertReloader も返します。
+func NewConfig(cfg config.TLSConfig) (*tls.Config, *CertReloader, error) {
+	tc := &tls.Config{}
+
+	switch cfg.MinVersion {
+	case "1.3":
+		tc.MinVersion = tls.VersionTLS13
+	default:
+		tc.MinVersion = tls.VersionTLS12
+	}
+
+	switch cfg.ClientAuth {
+	case "optional":
+		tc.ClientAuth = tls.VerifyClientCertIfGiven
+	case "require":
+		tc.ClientAuth = tls.RequireAndVerifyClientCert
+	default:
+		tc.ClientAuth = tls.NoClientCert
+	}
+	if cfg.ClientCAFile != "" {
+		pem, err := os.ReadFile(cfg.ClientCAFile)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read client CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, nil, fmt.Errorf("no certificates found in %s", cfg.ClientCAFile)
+		}
+		tc.ClientCAs = pool
+	}
+
+	if cfg.SelfSigned {
+		cert, err := SelfSigned(cfg.SelfSignedHosts, 365*24*time.Hour)
+		if err != nil {
+			return nil, nil, err
+		}
+		tc.Certificates = []tls.Certificate{cert}
+		return tc, nil, nil
+	}
+
+	r, err := NewCertReloader(cfg.CertFile, cfg.KeyFile)
+	if err != nil {
+		return nil, nil, err
+	}
+	tc.GetCertificate = r.GetCertificate
+	return tc, r, nil
+}
+
+// CertReloader は証明書ファイルが更新された場合に読み込み直して提供します。
+type CertReloader struct {
+	certFile string
+	keyFile  string
+	cert     atomic.Pointer[tls.Certificate]
+	modTime  time.Time
+}
+
+// NewCertReloader は証明書と秘密鍵を読み込んだCertReloaderを生成します。
+func NewCertReloader(certFile, keyFile string) (*CertReloader, error) {
+	r := &CertReloader{certFile: certFile, keyFile: keyFile}
+	if _, err := r.reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// GetCertificate は tls.Config.GetCertificate に設定する関数です。
+func (r *CertReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	return r.cert.Load(), nil
+}
+
+// Watch は interval ごとに証明書ファイルの更新を確認し、更新されていれば読み込み直します。
+// 読み込みに失敗した場合は現在の証明書を使い続けます。ctx がキャンセルされるまで戻りません。
+func (r *CertReloader) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			reloaded, err := r.reload()
+			if err != nil {
+				slog.Error("failed to reload TLS certificate", "certFile", r.certFile, "error", err)
+				continue
+			}
+			if reloaded {
+				slog.Info("TLS certificate reloaded", "certFile", r.certFile)
+			}
+		}
+	}
+}
+
+// reload は証明書ファイルが前回から更新されていれば読み込み直します。
+func (r *CertReloader) reload() (bool, error) {
+	mt, err := latestModTime(r.certFile, r.keyFile)
+	if err != nil {
+		return false, err
+	}
+	if !mt.After(r.modTime) {
+		return false, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return false, fmt.Errorf("failed to load TLS certificate: %w", err)
+	}
+	r.cert.Store(&cert)
+	r.modTime = mt
+	return true, nil
+}
+
+// latestModTime はファイルの中で最も新しい更新日時を返します。
+func latestModTime(files ...string) (time.Time, error) {
+	var latest time.Time
+	for _, f := range files {
+		fi, err := os.Stat(f)
+		if err != nil {
+			return latest, errors.Join(fmt.Errorf("failed to stat %s", f), err)
+		}
+		if fi.ModTime().After(latest) {
+			latest = fi.ModTime()
+		}
+	}
+	return latest, nil
+}
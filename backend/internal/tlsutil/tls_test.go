@@ -0,0 +1,68 @@
+package tlsutil
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"react-ts/backend/config"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_SelfSigned(t *testing.T) {
+	cert, err := SelfSigned([]string{"localhost", "127.0.0.1"}, time.Hour)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"localhost"}, cert.Leaf.DNSNames)
+	assert.Len(t, cert.Leaf.IPAddresses, 1)
+	assert.NoError(t, cert.Leaf.VerifyHostname("127.0.0.1"))
+}
+
+func Test_NewConfig(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+	writeCert(t, certFile, keyFile, "first.example")
+
+	tc, r, err := NewConfig(config.TLSConfig{
+		CertFile:     certFile,
+		KeyFile:      keyFile,
+		MinVersion:   "1.3",
+		ClientAuth:   "require",
+		ClientCAFile: certFile,
+	})
+	require.NoError(t, err)
+	assert.EqualValues(t, tls.VersionTLS13, tc.MinVersion)
+	assert.Equal(t, tls.RequireAndVerifyClientCert, tc.ClientAuth)
+	assert.NotNil(t, tc.ClientCAs)
+
+	// 証明書ファイルが更新されると読み込み直すこと
+	cert, _ := tc.GetCertificate(nil)
+	assert.Equal(t, "first.example", cert.Leaf.DNSNames[0])
+
+	writeCert(t, certFile, keyFile, "second.example")
+	future := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(certFile, future, future))
+	reloaded, err := r.reload()
+	require.NoError(t, err)
+	assert.True(t, reloaded)
+
+	cert, _ = tc.GetCertificate(nil)
+	assert.Equal(t, "second.example", cert.Leaf.DNSNames[0])
+}
+
+// writeCert は自己署名証明書をPEM形式でファイルに書き込みます
+func writeCert(t *testing.T, certFile, keyFile, host string) {
+	t.Helper()
+	cert, err := SelfSigned([]string{host}, time.Hour)
+	require.NoError(t, err)
+	key, err := x509.MarshalPKCS8PrivateKey(cert.PrivateKey)
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Certificate[0]}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: key}), 0o600))
+}
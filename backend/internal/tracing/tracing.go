@@ -0,0 +1,108 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"react-ts/backend/config"
+	"react-ts/backend/internal/errs"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ServiceName はトレースに記録するサービス名です
+const ServiceName = "react-ts-backend"
+
+// Setup は設定に応じたエクスポーターでTracerProviderを生成し、グローバルに登録します。
+// 戻り値の関数はアプリケーション終了時に呼び出し、未送信のスパンを送信してください。
+func Setup(ctx context.Context, cfg config.Config) (func(context.Context) error, error) {
+	// W3C traceparent / baggage を伝搬する
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	exp, closer, err := newExporter(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	if exp == nil {
+		// エクスポーターが無効な場合はデフォルト（何もしない）のTracerProviderを使用する
+		return func(context.Context) error { return nil }, nil
+	}
+
+	res := resource.NewSchemaless(semconv.ServiceName(ServiceName))
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exp),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(tp)
+
+	return func(ctx context.Context) error {
+		err := tp.Shutdown(ctx)
+		if closer != nil {
+			err = errors.Join(err, closer.Close())
+		}
+		return err
+	}, nil
+}
+
+// newExporter は設定に応じたエクスポーターを生成します
+func newExporter(ctx context.Context, cfg config.Config) (sdktrace.SpanExporter, io.Closer, error) {
+	switch cfg.Trace.Exporter {
+	case "", "none":
+		return nil, nil, nil
+	case "stdout":
+		exp, err := stdouttrace.New(stdouttrace.WithPrettyPrint())
+		return exp, nil, err
+	case "file":
+		f, err := os.OpenFile(cfg.Trace.File, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to open trace file: %w", err)
+		}
+		exp, err := stdouttrace.New(stdouttrace.WithWriter(f))
+		return exp, f, err
+	case "otlp":
+		var opts []otlptracehttp.Option
+		if cfg.Trace.OTLPEndpoint != "" {
+			opts = append(opts, otlptracehttp.WithEndpointURL(cfg.Trace.OTLPEndpoint))
+		}
+		exp, err := otlptracehttp.New(ctx, opts...)
+		return exp, nil, err
+	default:
+		return nil, nil, fmt.Errorf("unknown trace exporter: %q", cfg.Trace.Exporter)
+	}
+}
+
+// RecordError はスパンにエラーを記録します。
+// 業務エラーの場合はエラーコードと詳細を、システムエラーの場合はエラーコードを属性として付与します。
+func RecordError(span trace.Span, err error) {
+	if err == nil {
+		return
+	}
+
+	code := errs.Internal
+	var be *errs.BusinessError
+	var se *errs.SystemError
+	switch {
+	case errors.As(err, &be):
+		code = be.GetCode()
+		span.SetAttributes(attribute.StringSlice("error.details", be.GetDetails()))
+	case errors.As(err, &se):
+		code = se.GetCode()
+	}
+	span.SetAttributes(attribute.String("error.code", string(code)))
+	span.RecordError(err)
+	span.SetStatus(codes.Error, string(code))
+}
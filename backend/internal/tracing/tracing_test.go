@@ -0,0 +1,46 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"react-ts/backend/internal/errs"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func Test_RecordError(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		expectedCode errs.ErrorCode
+	}{
+		{name: "BusinessError", err: errs.NewBusinessError(errs.NotFound, "xxxx"), expectedCode: errs.NotFound},
+		{name: "SystemError", err: errs.NewSystemError("zzzzz", errors.New("error")), expectedCode: errs.Internal},
+		{name: "error", err: errors.New("error"), expectedCode: errs.Internal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sr := tracetest.NewSpanRecorder()
+			tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+
+			_, span := tp.Tracer("test").Start(context.Background(), "test")
+			RecordError(span, tt.err)
+			span.End()
+
+			assert := assert.New(t)
+			ended := sr.Ended()
+			if assert.Len(ended, 1) {
+				s := ended[0]
+				assert.Equal(codes.Error, s.Status().Code)
+				assert.Contains(s.Attributes(), attribute.String("error.code", string(tt.expectedCode)))
+				assert.Len(s.Events(), 1)
+			}
+		})
+	}
+}
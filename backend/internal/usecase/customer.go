@@ -0,0 +1,75 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"react-ts/backend/internal/domain"
+	"react-ts/backend/internal/tracing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// NewCustomerUseCase はお客さまのユースケースを生成します
+// 位置・作業区の変更は、移動元と移動先の作業区の事業所に通知します（事業所をまたぐ移動の場合は両方の事業所に通知します）
+func NewCustomerUseCase(tx domain.TxManager, repo domain.CustomerRepository, workZoneRepo domain.WorkZoneRepository, publisher domain.EventPublisher) domain.CustomerUseCase {
+	return &customerUseCase{
+		tx:           tx,
+		repo:         repo,
+		workZoneRepo: workZoneRepo,
+		publisher:    publisher,
+		now:          time.Now,
+	}
+}
+
+type customerUseCase struct {
+	tx           domain.TxManager
+	repo         domain.CustomerRepository
+	workZoneRepo domain.WorkZoneRepository
+	publisher    domain.EventPublisher
+	now          func() time.Time
+}
+
+func (u *customerUseCase) MoveCustomer(ctx context.Context, m domain.CustomerMoved) error {
+	ctx, span := tracer.Start(ctx, "CustomerUseCase.MoveCustomer",
+		trace.WithAttributes(attribute.String("customerId", m.CustomerID), attribute.String("workZoneId", m.WorkZoneID)))
+	defer span.End()
+
+	var from, to domain.WorkZone
+	err := u.tx.WithinTx(ctx, func(ctx context.Context) error {
+		customers, err := u.repo.GetCustomers(ctx, domain.CustomerFilter{IDs: []string{m.CustomerID}})
+		if err != nil {
+			return err
+		}
+		if len(customers) == 0 {
+			return fmt.Errorf("customer %s: %w", m.CustomerID, domain.ErrNotFound)
+		}
+		if from, err = u.workZoneRepo.GetWorkZone(ctx, customers[0].WorkZoneID); err != nil {
+			return err
+		}
+		if to, err = u.workZoneRepo.GetWorkZone(ctx, m.WorkZoneID); err != nil {
+			return err
+		}
+		return u.repo.UpdateCustomerLocation(ctx, m)
+	})
+	if err != nil {
+		err = wrapErr("CustomerUseCase.MoveCustomer", err)
+		tracing.RecordError(span, err)
+		return err
+	}
+	offices := []string{to.OfficeID}
+	if from.OfficeID != to.OfficeID {
+		offices = append(offices, from.OfficeID)
+	}
+	now := u.now()
+	for _, officeID := range offices {
+		u.publisher.Publish(ctx, domain.Event{
+			Type:       domain.EventCustomerMoved,
+			OfficeID:   officeID,
+			OccurredAt: now,
+			Data:       m,
+		})
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package usecase
+
+import (
+	"context"
+	"react-ts/backend/internal/domain"
+	"react-ts/backend/internal/tracing"
+)
+
+func NewOfficeUseCase(repo domain.OfficeRepository) domain.OfficeUseCase {
+	return &officeUseCase{
+		repo: repo,
+	}
+}
+
+type officeUseCase struct {
+	repo domain.OfficeRepository
+}
+
+func (u *officeUseCase) GetOffices(ctx context.Context) (domain.Offices, error) {
+	ctx, span := tracer.Start(ctx, "OfficeUseCase.GetOffices")
+	defer span.End()
+
+	md, err := u.repo.GetOffices(ctx)
+	if err != nil {
+		err = wrapErr("OfficeUseCase.GetOffices", err)
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return md, nil
+}
@@ -0,0 +1,135 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"react-ts/backend/internal/domain"
+	"react-ts/backend/internal/errs"
+	"react-ts/backend/internal/tracing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// PositionOptions は測位結果を保存する条件です
+type PositionOptions struct {
+	// 調査員ごとに測位結果を保存する最小の間隔（短い間隔で送信された測位結果は保存せずに破棄します）
+	MinInterval time.Duration
+	// 受信日時より後の測位日時を許容する時間（端末の時計のずれ）
+	MaxClockSkew time.Duration
+	// 受信日時より前の測位日時を許容する時間（通信できない間に溜まった測位結果の送信の遅れ）
+	MaxAge time.Duration
+}
+
+// NewPositionUseCase は調査員の位置のユースケースを生成します
+// opts.MinInterval より短い間隔で送信された測位結果は保存せずに破棄します（端末の送信間隔に依存せずに負荷を抑えるため）
+// 測位日時が受信日時から opts.MaxClockSkew・opts.MaxAge の範囲外の測位結果は不正なリクエストとして拒否します
+// 保存した測位結果は loc での測位の日付の軌跡にも追加します
+func NewPositionUseCase(tx domain.TxManager, repo domain.PositionRepository, trackRepo domain.TrackRepository, publisher domain.EventPublisher, opts PositionOptions, loc *time.Location) domain.PositionUseCase {
+	return &positionUseCase{
+		tx:        tx,
+		repo:      repo,
+		trackRepo: trackRepo,
+		publisher: publisher,
+		opts:      opts,
+		loc:       loc,
+		now:       time.Now,
+	}
+}
+
+type positionUseCase struct {
+	tx        domain.TxManager
+	repo      domain.PositionRepository
+	trackRepo domain.TrackRepository
+	publisher domain.EventPublisher
+	opts      PositionOptions
+	loc       *time.Location
+	now       func() time.Time
+}
+
+func (u *positionUseCase) RecordPosition(ctx context.Context, p domain.Position) (bool, error) {
+	ctx, span := tracer.Start(ctx, "PositionUseCase.RecordPosition",
+		trace.WithAttributes(attribute.String("surveyorId", p.SurveyorID)))
+	defer span.End()
+
+	p.ReceivedAt = u.now()
+	// 時計がずれた端末の測位結果で最新の位置や軌跡（日付）を誤らせないよう、保存する前に拒否する
+	if err := u.checkRecordedAt(p); err != nil {
+		tracing.RecordError(span, err)
+		return false, err
+	}
+
+	prev, err := u.repo.GetLatestPosition(ctx, p.SurveyorID)
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+	case err != nil:
+		err = wrapErr("PositionUseCase.RecordPosition", err)
+		tracing.RecordError(span, err)
+		return false, err
+	case !p.RecordedAt.After(prev.RecordedAt), p.ReceivedAt.Sub(prev.ReceivedAt) < u.opts.MinInterval:
+		span.SetAttributes(attribute.Bool("throttled", true))
+		return false, nil
+	}
+
+	err = u.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := u.repo.SaveLatestPosition(ctx, p); err != nil {
+			return err
+		}
+		return u.trackRepo.AppendTrackPoint(ctx, p.SurveyorID, p.RecordedAt.In(u.loc), domain.TrackPoint{
+			Lat:        p.Lat,
+			Lng:        p.Lng,
+			Accuracy:   p.Accuracy,
+			RecordedAt: p.RecordedAt,
+		})
+	})
+	if err != nil {
+		err = wrapErr("PositionUseCase.RecordPosition", err)
+		tracing.RecordError(span, err)
+		return false, err
+	}
+	u.publisher.Publish(ctx, domain.Event{
+		Type:       domain.EventPositionUpdated,
+		OfficeID:   p.OfficeID,
+		OccurredAt: p.RecordedAt,
+		Data:       p,
+	})
+	return true, nil
+}
+
+// checkRecordedAt は測位日時が受信日時から許容する範囲内かを検証します
+func (u *positionUseCase) checkRecordedAt(p domain.Position) error {
+	var msg, rule string
+	var param time.Duration
+	switch {
+	case p.RecordedAt.After(p.ReceivedAt.Add(u.opts.MaxClockSkew)):
+		msg, rule, param = "recordedAtは受信日時の%s後まででなければなりません", "max", u.opts.MaxClockSkew
+	case p.RecordedAt.Before(p.ReceivedAt.Add(-u.opts.MaxAge)):
+		msg, rule, param = "recordedAtは受信日時の%s前以降でなければなりません", "min", u.opts.MaxAge
+	default:
+		return nil
+	}
+	msg = fmt.Sprintf(msg, param)
+	return errs.NewBusinessError(errs.InvalidRequest, msg).WithViolations(errs.Violation{
+		Field:         "recordedAt",
+		Rule:          rule,
+		Param:         param.String(),
+		RejectedValue: p.RecordedAt,
+		Message:       msg,
+	})
+}
+
+func (u *positionUseCase) GetLatestPositions(ctx context.Context, officeID string) (domain.Positions, error) {
+	ctx, span := tracer.Start(ctx, "PositionUseCase.GetLatestPositions",
+		trace.WithAttributes(attribute.String("officeId", officeID)))
+	defer span.End()
+
+	md, err := u.repo.GetLatestPositions(ctx, officeID)
+	if err != nil {
+		err = wrapErr("PositionUseCase.GetLatestPositions", err)
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return md, nil
+}
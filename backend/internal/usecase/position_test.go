@@ -0,0 +1,142 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"react-ts/backend/internal/domain"
+	"react-ts/backend/internal/errs"
+	"react-ts/backend/internal/repository"
+	"react-ts/backend/internal/repository/memtx"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingPublisher struct {
+	events []domain.Event
+}
+
+func (p *recordingPublisher) Publish(ctx context.Context, e domain.Event) {
+	p.events = append(p.events, e)
+}
+
+func Test_RecordPosition(t *testing.T) {
+	ctx := context.Background()
+	pub := &recordingPublisher{}
+	tracks := repository.NewTrackRepository(0)
+	jst, _ := time.LoadLocation("Asia/Tokyo")
+	uc := NewPositionUseCase(memtx.New(), repository.NewPositionRepository(), tracks, pub, PositionOptions{MinInterval: 5 * time.Second, MaxClockSkew: time.Minute, MaxAge: time.Hour}, jst).(*positionUseCase)
+	// 日本時間では 10/20 の 0:00
+	now := time.Date(2026, 10, 19, 15, 0, 0, 0, time.UTC)
+	uc.now = func() time.Time { return now }
+
+	record := func(recordedAt time.Time) bool {
+		ok, err := uc.RecordPosition(ctx, domain.Position{SurveyorID: "000001", OfficeID: "XX", RecordedAt: recordedAt})
+		assert.NoError(t, err)
+		return ok
+	}
+
+	assert.True(t, record(now))
+	// 前回の保存からの間隔が短い場合は破棄する
+	now = now.Add(time.Second)
+	assert.False(t, record(now))
+	// 前回より古い測位結果は破棄する
+	now = now.Add(5 * time.Second)
+	assert.False(t, record(now.Add(-time.Minute)))
+	assert.True(t, record(now))
+
+	assert.Len(t, pub.events, 2)
+	assert.Equal(t, domain.EventPositionUpdated, pub.events[1].Type)
+	assert.Equal(t, "XX", pub.events[1].OfficeID)
+
+	md, err := uc.GetLatestPositions(ctx, "XX")
+	assert.NoError(t, err)
+	if assert.Len(t, md, 1) {
+		assert.Equal(t, now, md[0].RecordedAt)
+		assert.Equal(t, now, md[0].ReceivedAt)
+	}
+
+	// 保存した測位結果は日本時間の日付の軌跡に追加される
+	points, err := tracks.GetTrackPoints(ctx, "000001", time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+	assert.Len(t, points, 2)
+}
+
+func Test_RecordPosition_RecordedAt(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		recordedAt time.Time
+		ok         bool
+	}{
+		{name: "Now", recordedAt: now, ok: true},
+		{name: "MaxClockSkew", recordedAt: now.Add(time.Minute), ok: true},
+		// 時計が進んでいる端末の測位結果
+		{name: "Future", recordedAt: now.Add(time.Minute + time.Second), ok: false},
+		{name: "MaxAge", recordedAt: now.Add(-time.Hour), ok: true},
+		// 送信が遅れすぎた測位結果
+		{name: "Stale", recordedAt: now.Add(-time.Hour - time.Second), ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pub := &recordingPublisher{}
+			repo, tracks := repository.NewPositionRepository(), repository.NewTrackRepository(0)
+			uc := NewPositionUseCase(memtx.New(), repo, tracks, pub, PositionOptions{MaxClockSkew: time.Minute, MaxAge: time.Hour}, time.UTC).(*positionUseCase)
+			uc.now = func() time.Time { return now }
+
+			saved, err := uc.RecordPosition(ctx, domain.Position{SurveyorID: "000001", OfficeID: "XX", RecordedAt: tt.recordedAt})
+			assert := assert.New(t)
+			if tt.ok {
+				assert.True(saved)
+				assert.NoError(err)
+				return
+			}
+
+			assert.False(saved)
+			var be *errs.BusinessError
+			if assert.ErrorAs(err, &be) {
+				assert.Equal(errs.InvalidRequest, be.GetCode())
+				if assert.Len(be.GetViolations(), 1) {
+					assert.Equal("recordedAt", be.GetViolations()[0].Field)
+				}
+			}
+			// 拒否した測位結果は保存も通知もしない
+			_, err = repo.GetLatestPosition(ctx, "000001")
+			assert.ErrorIs(err, domain.ErrNotFound)
+			points, err := tracks.GetTrackPoints(ctx, "000001", tt.recordedAt)
+			assert.NoError(err)
+			assert.Empty(points)
+			assert.Empty(pub.events)
+		})
+	}
+}
+
+type failingTrackRepository struct {
+	domain.TrackRepository
+}
+
+func (failingTrackRepository) AppendTrackPoint(ctx context.Context, surveyorID string, date time.Time, p domain.TrackPoint) error {
+	return errors.New("failed")
+}
+
+func Test_RecordPosition_Rollback(t *testing.T) {
+	ctx := context.Background()
+	pub := &recordingPublisher{}
+	repo := repository.NewPositionRepository()
+	tx := memtx.New()
+	uc := NewPositionUseCase(tx, repo, failingTrackRepository{}, pub, PositionOptions{MaxClockSkew: time.Minute, MaxAge: time.Hour}, time.UTC)
+
+	ok, err := uc.RecordPosition(ctx, domain.Position{SurveyorID: "000001", OfficeID: "XX", RecordedAt: time.Now()})
+	assert.False(t, ok)
+	assert.Error(t, err)
+
+	// 軌跡に追加できなかった場合は最新の位置も保存せず、通知もしない
+	_, err = repo.GetLatestPosition(ctx, "000001")
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	assert.Empty(t, pub.events)
+	assert.Equal(t, int64(1), tx.Rollbacks.Load())
+}
@@ -1,7 +1,10 @@
 package usecase
 
 import (
+	"context"
 	"react-ts/backend/internal/domain"
+	"react-ts/backend/internal/logging"
+	"react-ts/backend/internal/tracing"
 )
 
 func NewSamplesUseCase(repo domain.SampleRepository) domain.SamplesUseCase {
@@ -14,11 +17,16 @@ type sampleUseCase struct {
 	repo domain.SampleRepository
 }
 
-func (u *sampleUseCase) GetSamples() (domain.Samples, error) {
-	md, err := u.repo.GetSamples()
+func (u *sampleUseCase) GetSamples(ctx context.Context) (domain.Samples, error) {
+	ctx, span := tracer.Start(ctx, "SamplesUseCase.GetSamples")
+	defer span.End()
+
+	md, err := u.repo.GetSamples(ctx)
 	if err != nil {
-		// TODO Errのラップ
+		err = wrapErr("SamplesUseCase.GetSamples", err)
+		tracing.RecordError(span, err)
 		return nil, err
 	}
+	logging.FromContext(ctx).DebugContext(ctx, "samples fetched", "count", len(md))
 	return md, nil
 }
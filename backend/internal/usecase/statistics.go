@@ -0,0 +1,43 @@
+package usecase
+
+import (
+	"context"
+	"react-ts/backend/internal/domain"
+	"react-ts/backend/internal/tracing"
+)
+
+func NewStatisticsUseCase(customerRepo domain.CustomerRepository, workZoneRepo domain.WorkZoneRepository) domain.StatisticsUseCase {
+	return &statisticsUseCase{
+		customerRepo: customerRepo,
+		workZoneRepo: workZoneRepo,
+	}
+}
+
+type statisticsUseCase struct {
+	customerRepo domain.CustomerRepository
+	workZoneRepo domain.WorkZoneRepository
+}
+
+func (u *statisticsUseCase) GetStatistics(ctx context.Context) (domain.Statistics, error) {
+	ctx, span := tracer.Start(ctx, "StatisticsUseCase.GetStatistics")
+	defer span.End()
+
+	var st domain.Statistics
+
+	byStatus, err := u.customerRepo.CountCustomersByStatus(ctx)
+	if err != nil {
+		err = wrapErr("StatisticsUseCase.GetStatistics", err)
+		tracing.RecordError(span, err)
+		return st, err
+	}
+	unassigned, err := u.workZoneRepo.CountUnassignedWorkZones(ctx)
+	if err != nil {
+		err = wrapErr("StatisticsUseCase.GetStatistics", err)
+		tracing.RecordError(span, err)
+		return st, err
+	}
+
+	st.CustomersByStatus = byStatus
+	st.UnassignedWorkZones = unassigned
+	return st, nil
+}
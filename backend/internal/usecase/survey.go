@@ -1,7 +1,13 @@
 package usecase
 
 import (
+	"context"
 	"react-ts/backend/internal/domain"
+	"react-ts/backend/internal/logging"
+	"react-ts/backend/internal/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 func NewSurveyUseCase(repo domain.SurveyRepository) domain.SurveyUseCase {
@@ -14,11 +20,30 @@ type surveyUseCase struct {
 	repo domain.SurveyRepository
 }
 
-func (u *surveyUseCase) GetSurveyors(filter domain.SurveyorFilter) (domain.Surveyors, error) {
-	md, err := u.repo.GetSurveyors(filter)
+func (u *surveyUseCase) GetSurveyors(ctx context.Context, filter domain.SurveyorFilter) (domain.Surveyors, error) {
+	ctx, span := tracer.Start(ctx, "SurveyUseCase.GetSurveyors",
+		trace.WithAttributes(attribute.String("filter.officeId", filter.OfficeID)))
+	defer span.End()
+
+	md, err := u.repo.GetSurveyors(ctx, filter)
 	if err != nil {
-		// TODO Errのラップ
+		err = wrapErr("SurveyUseCase.GetSurveyors", err)
+		tracing.RecordError(span, err)
 		return nil, err
 	}
+	logging.FromContext(ctx).DebugContext(ctx, "surveyors fetched", "officeId", filter.OfficeID, "count", len(md))
 	return md, nil
 }
+
+func (u *surveyUseCase) GetSurveyorsVersion(ctx context.Context) (string, error) {
+	ctx, span := tracer.Start(ctx, "SurveyUseCase.GetSurveyorsVersion")
+	defer span.End()
+
+	v, err := u.repo.GetSurveyorsVersion(ctx)
+	if err != nil {
+		err = wrapErr("SurveyUseCase.GetSurveyorsVersion", err)
+		tracing.RecordError(span, err)
+		return "", err
+	}
+	return v, nil
+}
@@ -0,0 +1,125 @@
+package usecase
+
+import (
+	"context"
+	"react-ts/backend/internal/domain"
+	"react-ts/backend/internal/geo"
+	"react-ts/backend/internal/tracing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TrackOptions は軌跡から滞在を検出する条件です
+type TrackOptions struct {
+	// 滞在とみなす範囲（メートル）
+	StopRadius float64
+	// 滞在とみなす最短の時間
+	StopMinDuration time.Duration
+	// 滞在した位置とお客さまの位置を対応付ける最大の距離（メートル）
+	CustomerMatchRadius float64
+}
+
+func NewTrackUseCase(trackRepo domain.TrackRepository, customerRepo domain.CustomerRepository, opts TrackOptions) domain.TrackUseCase {
+	return &trackUseCase{
+		trackRepo:    trackRepo,
+		customerRepo: customerRepo,
+		opts:         opts,
+	}
+}
+
+type trackUseCase struct {
+	trackRepo    domain.TrackRepository
+	customerRepo domain.CustomerRepository
+	opts         TrackOptions
+}
+
+func (u *trackUseCase) GetTrack(ctx context.Context, surveyorID string, date time.Time, tolerance float64) (domain.Track, error) {
+	ctx, span := tracer.Start(ctx, "TrackUseCase.GetTrack",
+		trace.WithAttributes(attribute.String("surveyorId", surveyorID), attribute.String("date", date.Format(time.DateOnly))))
+	defer span.End()
+
+	track := domain.Track{SurveyorID: surveyorID, Date: date}
+
+	points, err := u.trackRepo.GetTrackPoints(ctx, surveyorID, date)
+	if err != nil {
+		err = wrapErr("TrackUseCase.GetTrack", err)
+		tracing.RecordError(span, err)
+		return track, err
+	}
+	if len(points) == 0 {
+		return track, nil
+	}
+
+	timed := make([]geo.TimedPoint, len(points))
+	for i, p := range points {
+		timed[i] = geo.TimedPoint{Point: geo.Point{Lat: p.Lat, Lng: p.Lng}, Time: p.RecordedAt}
+	}
+	stops := geo.DetectStops(timed, u.opts.StopRadius, u.opts.StopMinDuration)
+
+	track.RawPointCount = len(points)
+	track.Duration = points[len(points)-1].RecordedAt.Sub(points[0].RecordedAt)
+	track.Distance = distance(timed, stops)
+	track.MovingDuration = track.Duration
+	for _, s := range stops {
+		track.MovingDuration -= s.Duration()
+	}
+
+	line := make([]geo.Point, len(timed))
+	for i, p := range timed {
+		line[i] = p.Point
+	}
+	for _, i := range geo.Simplify(line, tolerance) {
+		track.Points = append(track.Points, points[i])
+	}
+
+	if len(stops) > 0 {
+		customers, err := u.customerRepo.GetCustomers(ctx, domain.CustomerFilter{SurveyorID: surveyorID})
+		if err != nil {
+			err = wrapErr("TrackUseCase.GetTrack", err)
+			tracing.RecordError(span, err)
+			return track, err
+		}
+		for _, s := range stops {
+			track.Stops = append(track.Stops, domain.Stop{
+				Lat:        s.Center.Lat,
+				Lng:        s.Center.Lng,
+				Start:      s.Start,
+				End:        s.End,
+				CustomerID: nearestCustomer(customers, s.Center, u.opts.CustomerMatchRadius),
+			})
+		}
+	}
+
+	span.SetAttributes(attribute.Int("points", len(points)), attribute.Int("stops", len(stops)))
+	return track, nil
+}
+
+// distance は移動距離（メートル）を返します
+// 滞在中の測位の誤差によるぶれを移動距離に含めないよう、滞在中の点の間の距離は除きます
+func distance(points []geo.TimedPoint, stops []geo.Stop) float64 {
+	var d float64
+	s := 0
+	for i := 1; i < len(points); i++ {
+		for s < len(stops) && stops[s].Last < i {
+			s++
+		}
+		if s < len(stops) && stops[s].First <= i-1 && i <= stops[s].Last {
+			continue
+		}
+		d += geo.Distance(points[i-1].Point, points[i].Point)
+	}
+	return d
+}
+
+// nearestCustomer は p から radius メートル以内で最も近いお客さまのIDを返します（該当なしの場合は空）
+func nearestCustomer(customers domain.Customers, p geo.Point, radius float64) string {
+	id, nearest := "", radius
+	for _, c := range customers {
+		if d := geo.Distance(p, geo.Point{Lat: c.Lat, Lng: c.Lng}); d <= nearest {
+			id, nearest = c.ID, d
+		}
+	}
+	return id
+}
@@ -0,0 +1,74 @@
+package usecase
+
+import (
+	"context"
+	"react-ts/backend/internal/domain"
+	"react-ts/backend/internal/repository"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GetTrack(t *testing.T) {
+	ctx := context.Background()
+	tracks := repository.NewTrackRepository(0)
+	uc := NewTrackUseCase(tracks, repository.NewCustomerRepository(), TrackOptions{
+		StopRadius:          30,
+		StopMinDuration:     3 * time.Minute,
+		CustomerMatchRadius: 50,
+	})
+
+	date := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	start := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	// お客さま C00001 の位置から東へ east メートル
+	at := func(minute int, east float64) domain.TrackPoint {
+		return domain.TrackPoint{
+			Lat:        35.681236,
+			Lng:        139.767125 + east/(111195*0.8124),
+			RecordedAt: start.Add(time.Duration(minute) * time.Minute),
+		}
+	}
+	points := domain.TrackPoints{
+		// 西から直線で移動し、C00001 に10分間滞在した後、東へ移動する
+		at(0, -300), at(1, -200), at(2, -100),
+		at(3, 0), at(5, 3), at(8, -2), at(13, 1),
+		at(14, 100), at(15, 200), at(16, 300),
+	}
+	for _, p := range points {
+		assert.NoError(t, tracks.AppendTrackPoint(ctx, "000001", date, p))
+	}
+
+	track, err := uc.GetTrack(ctx, "000001", date, 5)
+	assert := assert.New(t)
+	assert.NoError(err)
+	assert.Equal(len(points), track.RawPointCount)
+	// 直線のため始点と終点のみ残る
+	assert.Equal(domain.TrackPoints{points[0], points[len(points)-1]}, track.Points)
+	assert.Equal(16*time.Minute, track.Duration)
+	assert.Equal(6*time.Minute, track.MovingDuration)
+	// 滞在中のぶれは移動距離に含めない
+	assert.InDelta(600, track.Distance, 2)
+	if assert.Len(track.Stops, 1) {
+		assert.Equal("C00001", track.Stops[0].CustomerID)
+		assert.Equal(at(3, 0).RecordedAt, track.Stops[0].Start)
+		assert.Equal(at(13, 0).RecordedAt, track.Stops[0].End)
+	}
+
+	// 担当でないお客さまとは対応付けない
+	for _, p := range points {
+		assert.NoError(tracks.AppendTrackPoint(ctx, "000002", date, p))
+	}
+	track, err = uc.GetTrack(ctx, "000002", date, 5)
+	assert.NoError(err)
+	if assert.Len(track.Stops, 1) {
+		assert.Empty(track.Stops[0].CustomerID)
+	}
+
+	// 測位結果がない日付
+	track, err = uc.GetTrack(ctx, "000001", date.AddDate(0, 0, 1), 5)
+	assert.NoError(err)
+	assert.Zero(track.RawPointCount)
+	assert.Empty(track.Points)
+	assert.Empty(track.Stops)
+}
@@ -0,0 +1,43 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"react-ts/backend/internal/domain"
+	"react-ts/backend/internal/errs"
+
+	"go.opentelemetry.io/otel"
+)
+
+var tracer = otel.Tracer("react-ts/backend/internal/usecase")
+
+// wrapErr はリポジトリなどから返されたエラーを、処理の名前（op）を付与した業務エラー・システムエラーに変換します
+//
+//   - domain.ErrNotFound などのリポジトリのエラーは、対応するエラーコードの業務エラーとします
+//   - domain.ErrUnavailable は errs.ServiceUnavailable のシステムエラーとします
+//   - 既に業務エラー・システムエラーである場合や、contextのタイムアウト・キャンセルの場合は分類を変えずにラップします
+//   - それ以外は errs.Internal のシステムエラーとします
+func wrapErr(op string, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var be *errs.BusinessError
+	var se *errs.SystemError
+	switch {
+	case errors.As(err, &be), errors.As(err, &se),
+		errors.Is(err, context.DeadlineExceeded), errors.Is(err, context.Canceled):
+		return fmt.Errorf("%s: %w", op, err)
+	case errors.Is(err, domain.ErrNotFound):
+		return errs.NewBusinessError(errs.NotFound).WithCause(fmt.Errorf("%s: %w", op, err))
+	case errors.Is(err, domain.ErrDuplicate):
+		return errs.NewBusinessError(errs.Duplicate).WithCause(fmt.Errorf("%s: %w", op, err))
+	case errors.Is(err, domain.ErrConflict):
+		return errs.NewBusinessError(errs.Conflict).WithCause(fmt.Errorf("%s: %w", op, err))
+	case errors.Is(err, domain.ErrUnavailable):
+		return errs.NewSystemError(op, err).WithCode(errs.ServiceUnavailable)
+	default:
+		return errs.NewSystemError(op, err)
+	}
+}
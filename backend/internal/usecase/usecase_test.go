@@ -0,0 +1,62 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"react-ts/backend/internal/domain"
+	"react-ts/backend/internal/errs"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_wrapErr(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected error
+	}{
+		{name: "NotFound", err: fmt.Errorf("survey 1: %w", domain.ErrNotFound), expected: errs.NewBusinessError(errs.NotFound)},
+		{name: "Duplicate", err: domain.ErrDuplicate, expected: errs.NewBusinessError(errs.Duplicate)},
+		{name: "Conflict", err: domain.ErrConflict, expected: errs.NewBusinessError(errs.Conflict)},
+		{name: "Unavailable", err: domain.ErrUnavailable, expected: errs.NewSystemError("", nil).WithCode(errs.ServiceUnavailable)},
+		{name: "Unknown", err: errors.New("broken"), expected: errs.NewSystemError("", nil)},
+		{name: "BusinessError", err: errs.NewBusinessError(errs.Forbidden), expected: errs.NewBusinessError(errs.Forbidden)},
+		{name: "DeadlineExceeded", err: context.DeadlineExceeded, expected: context.DeadlineExceeded},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := wrapErr("SurveyUseCase.GetSurveyors", tt.err)
+
+			assert.ErrorIs(t, err, tt.expected)
+			// 元のエラーも判定でき、処理の名前が付与されていること
+			assert.ErrorIs(t, err, tt.err)
+			assert.Contains(t, err.Error(), "SurveyUseCase.GetSurveyors: ")
+		})
+	}
+
+	assert.NoError(t, wrapErr("op", nil))
+}
+
+type stubSurveyRepository struct {
+	err error
+}
+
+func (r *stubSurveyRepository) GetSurveyors(ctx context.Context, filter domain.SurveyorFilter) (domain.Surveyors, error) {
+	return nil, r.err
+}
+
+func (r *stubSurveyRepository) GetSurveyorsVersion(ctx context.Context) (string, error) {
+	return "", r.err
+}
+
+func Test_GetSurveyors_Error(t *testing.T) {
+	uc := NewSurveyUseCase(&stubSurveyRepository{err: domain.ErrNotFound})
+
+	_, err := uc.GetSurveyors(context.Background(), domain.SurveyorFilter{})
+
+	assert.ErrorIs(t, err, errs.NewBusinessError(errs.NotFound))
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
@@ -0,0 +1,196 @@
+package usecase
+
+import (
+	"context"
+	"react-ts/backend/internal/domain"
+	"react-ts/backend/internal/geo"
+	"react-ts/backend/internal/tracing"
+	"slices"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// NewVisitUseCase は訪問結果のユースケースを生成します
+// 訪問結果を記録した位置は取得時に判定します（条件を変更した場合に過去の訪問結果にも適用されるよう）
+// 訪問結果の記録はコミット後に publisher に通知します
+// loc は軌跡を日付ごとに区切るタイムゾーンです
+func NewVisitUseCase(tx domain.TxManager, visitRepo domain.VisitRepository, customerRepo domain.CustomerRepository, trackRepo domain.TrackRepository, publisher domain.EventPublisher, loc *time.Location) domain.VisitUseCase {
+	return &visitUseCase{
+		tx:           tx,
+		visitRepo:    visitRepo,
+		customerRepo: customerRepo,
+		trackRepo:    trackRepo,
+		publisher:    publisher,
+		loc:          loc,
+	}
+}
+
+type visitUseCase struct {
+	tx           domain.TxManager
+	visitRepo    domain.VisitRepository
+	customerRepo domain.CustomerRepository
+	trackRepo    domain.TrackRepository
+	publisher    domain.EventPublisher
+	loc          *time.Location
+}
+
+func (u *visitUseCase) RecordVisit(ctx context.Context, v domain.Visit) error {
+	ctx, span := tracer.Start(ctx, "VisitUseCase.RecordVisit",
+		trace.WithAttributes(attribute.String("customerId", v.CustomerID), attribute.String("surveyorId", v.SurveyorID)))
+	defer span.End()
+
+	err := u.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := u.visitRepo.SaveVisit(ctx, v); err != nil {
+			return err
+		}
+		return u.customerRepo.UpdateCustomerStatus(ctx, v.CustomerID, v.Status)
+	})
+	if err != nil {
+		err = wrapErr("VisitUseCase.RecordVisit", err)
+		tracing.RecordError(span, err)
+		return err
+	}
+	u.publisher.Publish(ctx, domain.Event{
+		Type:       domain.EventVisitRecorded,
+		OfficeID:   v.OfficeID,
+		OccurredAt: v.RecordedAt,
+		Data: domain.VisitRecorded{
+			CustomerID: v.CustomerID,
+			SurveyorID: v.SurveyorID,
+			Status:     v.Status,
+		},
+	})
+	return nil
+}
+
+func (u *visitUseCase) GetVisitAnomalies(ctx context.Context, filter domain.VisitAnomalyFilter) (domain.VisitChecks, error) {
+	ctx, span := tracer.Start(ctx, "VisitUseCase.GetVisitAnomalies",
+		trace.WithAttributes(attribute.String("officeId", filter.OfficeID), attribute.String("date", filter.Date.Format(time.DateOnly))))
+	defer span.End()
+
+	from := time.Date(filter.Date.Year(), filter.Date.Month(), filter.Date.Day(), 0, 0, 0, 0, u.loc)
+	visits, err := u.visitRepo.GetVisits(ctx, domain.VisitFilter{
+		OfficeID:   filter.OfficeID,
+		SurveyorID: filter.SurveyorID,
+		From:       from,
+		To:         from.AddDate(0, 0, 1),
+	})
+	if err != nil {
+		err = wrapErr("VisitUseCase.GetVisitAnomalies", err)
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	if len(visits) == 0 {
+		return nil, nil
+	}
+
+	var ids []string
+	for _, v := range visits {
+		if !slices.Contains(ids, v.CustomerID) {
+			ids = append(ids, v.CustomerID)
+		}
+	}
+	customers, err := u.customerRepo.GetCustomers(ctx, domain.CustomerFilter{IDs: ids})
+	if err != nil {
+		err = wrapErr("VisitUseCase.GetVisitAnomalies", err)
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	byID := make(map[string]domain.Customer, len(customers))
+	for _, c := range customers {
+		byID[c.ID] = c
+	}
+
+	locations := filter.Locations
+	if len(locations) == 0 {
+		locations = []domain.VisitLocation{domain.VisitLocationNear, domain.VisitLocationFar, domain.VisitLocationNoFix}
+	}
+
+	tracks := trackCache{repo: u.trackRepo, points: map[string]domain.TrackPoints{}}
+	var ret domain.VisitChecks
+	for _, v := range visits {
+		var points domain.TrackPoints
+		if filter.Geofence.TrackWindow > 0 {
+			points, err = tracks.around(ctx, v.SurveyorID, v.RecordedAt.In(u.loc), filter.Geofence.TrackWindow)
+			if err != nil {
+				err = wrapErr("VisitUseCase.GetVisitAnomalies", err)
+				tracing.RecordError(span, err)
+				return nil, err
+			}
+		}
+		c, ok := byID[v.CustomerID]
+		check := checkVisit(v, c, ok, points, filter.Geofence)
+		if slices.Contains(locations, check.Location) {
+			ret = append(ret, check)
+		}
+	}
+
+	span.SetAttributes(attribute.Int("visits", len(visits)), attribute.Int("anomalies", len(ret)))
+	return ret, nil
+}
+
+// checkVisit は訪問結果を記録した位置を分類します
+// 記録時の測位結果と前後の軌跡のうち、お客さまの位置から遠い方の距離で分類します（測位結果だけが現地でも、軌跡がお客さまに近づいていない場合は疑わしいため）
+// お客さまの位置が不明な場合は確認できないため NO_FIX とします
+func checkVisit(v domain.Visit, c domain.Customer, found bool, points domain.TrackPoints, g domain.Geofence) domain.VisitCheck {
+	check := domain.VisitCheck{Visit: v, Location: domain.VisitLocationNoFix}
+	if !found {
+		return check
+	}
+
+	customer := geo.Point{Lat: c.Lat, Lng: c.Lng}
+	if v.Fix != nil {
+		d := geo.Distance(customer, geo.Point{Lat: v.Fix.Lat, Lng: v.Fix.Lng})
+		check.FixDistance = &d
+	}
+	for _, p := range points {
+		d := geo.Distance(customer, geo.Point{Lat: p.Lat, Lng: p.Lng})
+		if check.TrackDistance == nil || d < *check.TrackDistance {
+			check.TrackDistance = &d
+		}
+	}
+
+	switch {
+	case check.FixDistance != nil && check.TrackDistance != nil:
+		check.Location = g.Classify(max(*check.FixDistance, *check.TrackDistance))
+	case check.FixDistance != nil:
+		check.Location = g.Classify(*check.FixDistance)
+	case check.TrackDistance != nil:
+		check.Location = g.Classify(*check.TrackDistance)
+	}
+	return check
+}
+
+// trackCache は1回の処理の中で同じ調査員・日付の軌跡を繰り返し取得しないよう保持します
+type trackCache struct {
+	repo   domain.TrackRepository
+	points map[string]domain.TrackPoints
+}
+
+// around は調査員の at の前後 window の軌跡を返します（日付をまたぐ場合は両日の軌跡から取得します）
+func (c *trackCache) around(ctx context.Context, surveyorID string, at time.Time, window time.Duration) (domain.TrackPoints, error) {
+	from, to := at.Add(-window), at.Add(window)
+	var ret domain.TrackPoints
+	for _, date := range []time.Time{from, to} {
+		key := surveyorID + "/" + date.Format(time.DateOnly)
+		points, ok := c.points[key]
+		if !ok {
+			var err error
+			if points, err = c.repo.GetTrackPoints(ctx, surveyorID, date); err != nil {
+				return nil, err
+			}
+			c.points[key] = points
+		}
+		for _, p := range points {
+			if !p.RecordedAt.Before(from) && !p.RecordedAt.After(to) {
+				ret = append(ret, p)
+			}
+		}
+		if from.Format(time.DateOnly) == to.Format(time.DateOnly) {
+			break
+		}
+	}
+	return ret, nil
+}
@@ -0,0 +1,128 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"react-ts/backend/internal/domain"
+	"react-ts/backend/internal/repository"
+	"react-ts/backend/internal/repository/memtx"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GetVisitAnomalies(t *testing.T) {
+	ctx := context.Background()
+	jst, _ := time.LoadLocation("Asia/Tokyo")
+	date := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	geofence := domain.Geofence{OnSiteRadius: 50, NearRadius: 200, TrackWindow: 10 * time.Minute}
+
+	locations := func(md domain.VisitChecks) map[string]domain.VisitLocation {
+		ret := map[string]domain.VisitLocation{}
+		for _, m := range md {
+			ret[m.ID] = m.Location
+		}
+		return ret
+	}
+
+	t.Run("FixOnly", func(t *testing.T) {
+		uc := NewVisitUseCase(memtx.New(), repository.NewVisitRepository(), repository.NewCustomerRepository(), repository.NewTrackRepository(0), &recordingPublisher{}, jst)
+
+		md, err := uc.GetVisitAnomalies(ctx, domain.VisitAnomalyFilter{OfficeID: "XX", Date: date, Geofence: geofence})
+		assert.NoError(t, err)
+		// 現地で記録された V00001 は含まない
+		assert.Equal(t, map[string]domain.VisitLocation{
+			"V00002": domain.VisitLocationNear,
+			"V00003": domain.VisitLocationFar,
+			"V00004": domain.VisitLocationNoFix,
+		}, locations(md))
+		for _, m := range md {
+			assert.Equal(t, m.Fix != nil, m.FixDistance != nil)
+			assert.Nil(t, m.TrackDistance)
+		}
+	})
+
+	t.Run("Track", func(t *testing.T) {
+		tracks := repository.NewTrackRepository(0)
+		// V00001（10:00 に C00001 で記録）の前後は C00001 から約1km離れた位置にいた
+		for _, minute := range []int{55, 60, 65} {
+			at := time.Date(2026, 10, 19, 9, 0, 0, 0, jst).Add(time.Duration(minute) * time.Minute)
+			assert.NoError(t, tracks.AppendTrackPoint(ctx, "000001", at, domain.TrackPoint{Lat: 35.690236, Lng: 139.767125, RecordedAt: at}))
+		}
+		// V00004（16:00 に測位結果なしで記録）の直前は C00001 にいた
+		at := time.Date(2026, 10, 19, 15, 58, 0, 0, jst)
+		assert.NoError(t, tracks.AppendTrackPoint(ctx, "000001", at, domain.TrackPoint{Lat: 35.681236, Lng: 139.767125, RecordedAt: at}))
+
+		uc := NewVisitUseCase(memtx.New(), repository.NewVisitRepository(), repository.NewCustomerRepository(), tracks, &recordingPublisher{}, jst)
+		md, err := uc.GetVisitAnomalies(ctx, domain.VisitAnomalyFilter{
+			OfficeID:   "XX",
+			SurveyorID: "000001",
+			Date:       date,
+			Locations:  []domain.VisitLocation{domain.VisitLocationOnSite, domain.VisitLocationFar},
+			Geofence:   geofence,
+		})
+		assert.NoError(t, err)
+		// 測位結果が現地でも軌跡が近づいていない場合は遠方、測位結果がなくても軌跡が現地の場合は現地とする
+		assert.Equal(t, map[string]domain.VisitLocation{
+			"V00001": domain.VisitLocationFar,
+			"V00004": domain.VisitLocationOnSite,
+		}, locations(md))
+		for _, m := range md {
+			if assert.NotNil(t, m.TrackDistance) && m.ID == "V00001" {
+				assert.InDelta(t, 1000, *m.TrackDistance, 5)
+				assert.Less(t, *m.FixDistance, 50.0)
+			}
+		}
+	})
+
+	t.Run("OtherDate", func(t *testing.T) {
+		uc := NewVisitUseCase(memtx.New(), repository.NewVisitRepository(), repository.NewCustomerRepository(), repository.NewTrackRepository(0), &recordingPublisher{}, jst)
+
+		md, err := uc.GetVisitAnomalies(ctx, domain.VisitAnomalyFilter{OfficeID: "XX", Date: date.AddDate(0, 0, 1), Geofence: geofence})
+		assert.NoError(t, err)
+		assert.Empty(t, md)
+	})
+}
+
+type failingCustomerRepository struct {
+	domain.CustomerRepository
+}
+
+func (failingCustomerRepository) UpdateCustomerStatus(ctx context.Context, id string, status domain.CustomerStatus) error {
+	return errors.New("failed")
+}
+
+func Test_RecordVisit(t *testing.T) {
+	ctx := context.Background()
+	at := time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)
+	v := domain.Visit{CustomerID: "C00001", SurveyorID: "000001", OfficeID: "XX", Status: domain.CustomerStatusVisited, RecordedAt: at}
+
+	pub := &recordingPublisher{}
+	uc := NewVisitUseCase(memtx.New(), repository.NewVisitRepository(), repository.NewCustomerRepository(), repository.NewTrackRepository(0), pub, time.UTC)
+	assert.NoError(t, uc.RecordVisit(ctx, v))
+	if assert.Len(t, pub.events, 1) {
+		assert.Equal(t, domain.EventVisitRecorded, pub.events[0].Type)
+		assert.Equal(t, "XX", pub.events[0].OfficeID)
+		assert.Equal(t, at, pub.events[0].OccurredAt)
+		assert.Equal(t, domain.VisitRecorded{CustomerID: "C00001", SurveyorID: "000001", Status: domain.CustomerStatusVisited}, pub.events[0].Data)
+	}
+
+	// お客さまの訪問状況を更新できなかった場合はロールバックし、通知しない
+	pub = &recordingPublisher{}
+	tx := memtx.New()
+	uc = NewVisitUseCase(tx, repository.NewVisitRepository(), failingCustomerRepository{}, repository.NewTrackRepository(0), pub, time.UTC)
+	assert.Error(t, uc.RecordVisit(ctx, v))
+	assert.Empty(t, pub.events)
+	assert.EqualValues(t, 1, tx.Rollbacks.Load())
+}
+
+func Test_Geofence_Classify(t *testing.T) {
+	g := domain.Geofence{OnSiteRadius: 50, NearRadius: 200}
+
+	assert.Equal(t, domain.VisitLocationOnSite, g.Classify(0))
+	assert.Equal(t, domain.VisitLocationOnSite, g.Classify(50))
+	assert.Equal(t, domain.VisitLocationNear, g.Classify(50.1))
+	assert.Equal(t, domain.VisitLocationNear, g.Classify(200))
+	assert.Equal(t, domain.VisitLocationFar, g.Classify(200.1))
+}
@@ -0,0 +1,86 @@
+package usecase
+
+import (
+	"context"
+	"react-ts/backend/internal/domain"
+	"react-ts/backend/internal/tracing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// NewWorkZoneUseCase は作業区のユースケースを生成します
+// 担当の変更はコミット後に cache の参照結果（未割り当ての作業区の数など）を破棄し、publisher に通知します
+func NewWorkZoneUseCase(tx domain.TxManager, repo domain.WorkZoneRepository, cache domain.CacheInvalidator, publisher domain.EventPublisher) domain.WorkZoneUseCase {
+	return &workZoneUseCase{
+		tx:        tx,
+		repo:      repo,
+		cache:     cache,
+		publisher: publisher,
+		now:       time.Now,
+	}
+}
+
+type workZoneUseCase struct {
+	tx        domain.TxManager
+	repo      domain.WorkZoneRepository
+	cache     domain.CacheInvalidator
+	publisher domain.EventPublisher
+	now       func() time.Time
+}
+
+func (u *workZoneUseCase) GetWorkZones(ctx context.Context, filter domain.WorkZoneFilter) (domain.WorkZones, error) {
+	ctx, span := tracer.Start(ctx, "WorkZoneUseCase.GetWorkZones",
+		trace.WithAttributes(attribute.String("filter.officeId", filter.OfficeID)))
+	defer span.End()
+
+	md, err := u.repo.GetWorkZones(ctx, filter)
+	if err != nil {
+		err = wrapErr("WorkZoneUseCase.GetWorkZones", err)
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return md, nil
+}
+
+func (u *workZoneUseCase) AssignSurveyor(ctx context.Context, workZoneID, surveyorID string) (domain.WorkZone, error) {
+	ctx, span := tracer.Start(ctx, "WorkZoneUseCase.AssignSurveyor",
+		trace.WithAttributes(attribute.String("workZoneId", workZoneID), attribute.String("surveyorId", surveyorID)))
+	defer span.End()
+
+	var prev, z domain.WorkZone
+	err := u.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		if prev, err = u.repo.GetWorkZone(ctx, workZoneID); err != nil {
+			return err
+		}
+		z = prev
+		if prev.SurveyorID == surveyorID {
+			return nil
+		}
+		z.SurveyorID = surveyorID
+		return u.repo.UpdateWorkZoneSurveyor(ctx, workZoneID, surveyorID)
+	})
+	if err != nil {
+		err = wrapErr("WorkZoneUseCase.AssignSurveyor", err)
+		tracing.RecordError(span, err)
+		return domain.WorkZone{}, err
+	}
+	// 担当が変わらない場合は通知しない
+	if prev.SurveyorID == surveyorID {
+		return z, nil
+	}
+	u.cache.InvalidateCache(ctx)
+	u.publisher.Publish(ctx, domain.Event{
+		Type:       domain.EventZoneAssigned,
+		OfficeID:   z.OfficeID,
+		OccurredAt: u.now(),
+		Data: domain.ZoneAssigned{
+			WorkZoneID:         z.ID,
+			SurveyorID:         z.SurveyorID,
+			PreviousSurveyorID: prev.SurveyorID,
+		},
+	})
+	return z, nil
+}
@@ -0,0 +1,83 @@
+package usecase
+
+import (
+	"context"
+	"react-ts/backend/internal/domain"
+	"react-ts/backend/internal/errs"
+	"react-ts/backend/internal/repository"
+	"react-ts/backend/internal/repository/memtx"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type countingInvalidator struct {
+	calls int
+}
+
+func (c *countingInvalidator) InvalidateCache(ctx context.Context) {
+	c.calls++
+}
+
+func Test_AssignSurveyor(t *testing.T) {
+	ctx := context.Background()
+	pub := &recordingPublisher{}
+	tx := memtx.New()
+	cache := &countingInvalidator{}
+	uc := NewWorkZoneUseCase(tx, repository.NewWorkZoneRepository(), cache, pub)
+
+	z, err := uc.AssignSurveyor(ctx, "Z00001", "000002")
+	assert.NoError(t, err)
+	assert.Equal(t, "000002", z.SurveyorID)
+	if assert.Len(t, pub.events, 1) {
+		assert.Equal(t, domain.EventZoneAssigned, pub.events[0].Type)
+		assert.Equal(t, "XX", pub.events[0].OfficeID)
+		assert.Equal(t, domain.ZoneAssigned{WorkZoneID: "Z00001", SurveyorID: "000002", PreviousSurveyorID: "000001"}, pub.events[0].Data)
+	}
+	// 未割り当ての作業区の数が変わるため、キャッシュを破棄する
+	assert.Equal(t, 1, cache.calls)
+
+	// 担当が変わらない場合は通知しない
+	_, err = uc.AssignSurveyor(ctx, "Z00002", "000002")
+	assert.NoError(t, err)
+	assert.Len(t, pub.events, 1)
+
+	// 作業区が存在しない場合はロールバックし、通知しない
+	_, err = uc.AssignSurveyor(ctx, "Z99999", "000002")
+	assert.ErrorIs(t, err, errs.NewBusinessError(errs.NotFound))
+	assert.Len(t, pub.events, 1)
+	assert.Equal(t, 1, cache.calls)
+	assert.EqualValues(t, 1, tx.Rollbacks.Load())
+}
+
+func Test_MoveCustomer(t *testing.T) {
+	ctx := context.Background()
+	pub := &recordingPublisher{}
+	uc := NewCustomerUseCase(memtx.New(), repository.NewCustomerRepository(), repository.NewWorkZoneRepository(), pub)
+
+	m := domain.CustomerMoved{CustomerID: "C00001", WorkZoneID: "Z00002", Lat: 35.7, Lng: 139.7}
+	assert.NoError(t, uc.MoveCustomer(ctx, m))
+	if assert.Len(t, pub.events, 1) {
+		assert.Equal(t, domain.EventCustomerMoved, pub.events[0].Type)
+		// 移動先の作業区の事業所に通知する
+		assert.Equal(t, "XX", pub.events[0].OfficeID)
+		assert.Equal(t, m, pub.events[0].Data)
+	}
+
+	// 事業所をまたぐ移動は移動先と移動元の両方の事業所に通知する
+	m = domain.CustomerMoved{CustomerID: "C00001", WorkZoneID: "Z00004", Lat: 35.7, Lng: 139.7}
+	assert.NoError(t, uc.MoveCustomer(ctx, m))
+	if assert.Len(t, pub.events, 3) {
+		assert.Equal(t, "YY", pub.events[1].OfficeID)
+		assert.Equal(t, "XX", pub.events[2].OfficeID)
+		assert.Equal(t, m, pub.events[1].Data)
+		assert.Equal(t, m, pub.events[2].Data)
+	}
+
+	// 移動先の作業区・お客さまが存在しない場合は通知しない
+	err := uc.MoveCustomer(ctx, domain.CustomerMoved{CustomerID: "C00001", WorkZoneID: "Z99999"})
+	assert.ErrorIs(t, err, errs.NewBusinessError(errs.NotFound))
+	err = uc.MoveCustomer(ctx, domain.CustomerMoved{CustomerID: "C99999", WorkZoneID: "Z00002"})
+	assert.ErrorIs(t, err, errs.NewBusinessError(errs.NotFound))
+	assert.Len(t, pub.events, 3)
+}